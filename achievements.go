@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- ACHIEVEMENTS ---
+
+const toastDuration = 3 * time.Second
+
+var toastStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#000000")).
+	Background(accent).
+	Bold(true).
+	Padding(0, 1)
+
+// toastExpiredMsg hides the toast it was scheduled for, unless a newer
+// one has replaced it since.
+type toastExpiredMsg struct{ id int }
+
+func (m model) showToast(text string) (model, tea.Cmd) {
+	m.toastID++
+	m.toast = text
+	id := m.toastID
+	return m, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+func findAchievement(id string) (achievement, bool) {
+	for _, a := range achievements {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return achievement{}, false
+}
+
+// Every egg needs an achievement, otherwise finding it would go unnoticed
+func init() {
+	for _, e := range easterEggRegistry.eggs {
+		if _, ok := findAchievement(e.ID()); !ok {
+			log.Fatalf("easter egg %q has no achievement", e.ID())
+		}
+	}
+}
+
+// unlock records an achievement for the session and the visitor's key,
+// with a toast the first time.
+func (m model) unlock(id string) (model, tea.Cmd) {
+	if m.discovered[id] {
+		return m, nil
+	}
+	a, ok := findAchievement(id)
+	if !ok {
+		return m, nil
+	}
+	m.discovered[id] = true
+	m.store.Update(m.fingerprint, func(r *visitorRecord) {
+		if r.Achievements == nil {
+			r.Achievements = map[string]time.Time{}
+		}
+		r.Achievements[id] = time.Now()
+	})
+	return m.showToast("🏆 Achievement unlocked: " + a.Title)
+}
+
+// markViewed remembers that item's detail page was opened and unlocks
+// the milestone once every project has been seen.
+func (m model) markViewed(item Item) (model, tea.Cmd) {
+	if item.Category != "projects" || m.viewed[item.Slug] {
+		return m, nil
+	}
+	m.viewed[item.Slug] = true
+	m.store.Update(m.fingerprint, func(r *visitorRecord) {
+		if r.Viewed == nil {
+			r.Viewed = map[string]bool{}
+		}
+		r.Viewed[item.Slug] = true
+	})
+	for _, it := range items {
+		if it.Category == "projects" && !m.viewed[it.Slug] {
+			return m, nil
+		}
+	}
+	return m.unlock("all-projects")
+}
+
+func (m model) renderAchievements(contentWidth int) string {
+	var b strings.Builder
+
+	found := 0
+	for _, a := range achievements {
+		if m.discovered[a.ID] {
+			found++
+		}
+	}
+
+	section := sectionStyle.Render("▸ ACHIEVEMENTS")
+	b.WriteString(centerText(section, contentWidth))
+	b.WriteString("\n\n")
+	counts := fmt.Sprintf("%d found · %d locked", found, len(achievements)-found)
+	b.WriteString(centerText(techStyle.Render(counts), contentWidth))
+	b.WriteString("\n\n")
+
+	var lines []string
+	for _, a := range achievements {
+		if m.discovered[a.ID] {
+			lines = append(lines, titleStyle.Render("🏆 "+a.Title)+"  "+socialText.Render(a.Desc))
+		} else {
+			lines = append(lines, hintStyle.Render("🔒 ???"))
+		}
+	}
+	b.WriteString(centerBlock(lipgloss.JoinVertical(lipgloss.Left, lines...), contentWidth))
+	b.WriteString("\n\n")
+
+	hints := hintStyle.Render(m.keys.hints(false, hint{actBack, "back"}, hint{actQuit, "quit"}))
+	b.WriteString(centerText(hints, contentWidth))
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+)
+
+// --- CLIPBOARD ---
+
+// terminal is what we know about the visitor's terminal: TERM from the
+// pty request plus whatever environment their client chose to send.
+type terminal struct {
+	term    string
+	program string // TERM_PROGRAM
+	env     map[string]string
+}
+
+func sessionTerminal(s ssh.Session) terminal {
+	t := terminal{env: map[string]string{}}
+	for _, kv := range s.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			t.env[k] = v
+		}
+	}
+	if pty, _, ok := s.Pty(); ok {
+		t.term = pty.Term
+	}
+	if t.term == "" {
+		t.term = t.env["TERM"]
+	}
+	t.program = t.env["TERM_PROGRAM"]
+	return t
+}
+
+// Terminals known to ignore OSC 52. Anything else gets the benefit of the
+// doubt, since most modern terminals accept it.
+var (
+	noClipboardPrograms = map[string]bool{"Apple_Terminal": true}
+	noClipboardTerms    = map[string]bool{"linux": true, "dumb": true, "vt100": true, "vt220": true}
+)
+
+// canCopy reports whether the terminal should pick up OSC 52.
+func (t terminal) canCopy() bool {
+	if noClipboardPrograms[t.program] || noClipboardTerms[t.term] {
+		return false
+	}
+	// GNOME Terminal and other VTE based terminals don't support it
+	_, vte := t.env["VTE_VERSION"]
+	return !vte
+}
+
+// clipboardSequence wraps the escape for tmux or screen, which otherwise
+// swallow it instead of passing it on to the terminal outside.
+func (t terminal) clipboardSequence(text string) osc52.Sequence {
+	seq := osc52.New(text)
+	switch {
+	case strings.HasPrefix(t.term, "tmux"):
+		seq = seq.Tmux()
+	case strings.HasPrefix(t.term, "screen"):
+		seq = seq.Screen()
+	}
+	return seq
+}
+
+// syncWriter is the session's output, shared by the program's renderer
+// and escapes sent around it. The renderer writes each frame in one go, so
+// taking turns is enough to keep an escape from landing mid-frame.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// copyToClipboard puts text on the visitor's own clipboard. The OSC 52
+// escape travels over the SSH session and is handled by their terminal,
+// so it works wherever they're connecting from. Terminals that can't take
+// it get the text shown to select by hand instead.
+func (m model) copyToClipboard(text, what string) (model, tea.Cmd) {
+	if m.out == nil || !m.term.canCopy() {
+		m.copyFallback = text
+		return m, nil
+	}
+	m.copyFallback = ""
+	out, seq := m.out, m.term.clipboardSequence(text)
+	write := func() tea.Msg {
+		if _, err := seq.WriteTo(out); err != nil {
+			log.Printf("copying to clipboard: %v", err)
+		}
+		return nil
+	}
+	m, toast := m.showToast("📋 Copied " + what + " to your clipboard")
+	return m, tea.Batch(write, toast)
+}
+
+// copyLink copies the open item's link, if it has one.
+func (m model) copyLink() (model, tea.Cmd) {
+	item := items[m.cursor]
+	if item.Link == "" {
+		return m, nil
+	}
+	return m.copyToClipboard(item.Link, item.Title+"'s link")
+}
+
+var copyFallbackStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(accent).
+	Padding(0, 2).
+	Align(lipgloss.Center)
+
+// renderCopyFallback shows text for the visitor to select themselves.
+func renderCopyFallback(text string, width int) string {
+	lines := []string{
+		socialText.Render("Your terminal can't take copies from here,"),
+		socialText.Render("so select this by hand (hold shift to drag):"),
+		"",
+		titleStyle.Render(text),
+		"",
+		hintStyle.Render("esc to dismiss"),
+	}
+	return centerText(copyFallbackStyle.Render(strings.Join(lines, "\n")), width)
+}
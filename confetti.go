@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- CONFETTI ---
+
+const (
+	confettiCount   = 48
+	confettiGravity = 0.07 // rows per tick², cells are about twice as tall as wide
+	confettiDrag    = 0.97
+)
+
+var (
+	confettiColors = []lipgloss.Color{accent, accent2, green, cyan, lipgloss.Color("#7B68EE"), lipgloss.Color("#FFD700")}
+)
+
+type particle struct {
+	x, y, vx, vy float64
+	glyph        string
+	color        lipgloss.Color
+	life, max    int
+}
+
+// burstConfetti throws a handful of particles, drawn from g, up and out
+// from x, y.
+func burstConfetti(x, y int, g glyphSet) []particle {
+	ps := make([]particle, confettiCount)
+	for i := range ps {
+		p := particle{
+			x:     float64(x),
+			y:     float64(y),
+			vx:    (rand.Float64()*2 - 1) * 1.6,
+			vy:    -0.5 - rand.Float64()*1.1,
+			color: confettiColors[rand.Intn(len(confettiColors))],
+			max:   40 + rand.Intn(40),
+		}
+		// Mostly plain shapes that can fade, with the odd emoji
+		if rand.Intn(5) == 0 {
+			p.glyph = g.confettiEmoji[rand.Intn(len(g.confettiEmoji))]
+		} else {
+			p.glyph = g.confettiShapes[rand.Intn(len(g.confettiShapes))]
+		}
+		p.life = p.max
+		ps[i] = p
+	}
+	return ps
+}
+
+// stepConfetti moves every particle one tick and drops the ones that
+// burned out or fell off the screen.
+func stepConfetti(ps []particle, height int) []particle {
+	alive := ps[:0]
+	for _, p := range ps {
+		p.vy += confettiGravity
+		p.vx = p.vx*confettiDrag + (rand.Float64()-0.5)*0.08
+		p.x += p.vx
+		p.y += p.vy
+		p.life--
+		if p.life > 0 && p.y < float64(height) {
+			alive = append(alive, p)
+		}
+	}
+	return alive
+}
+
+// fadeColor dims c towards black as f goes from 1 to 0.
+func fadeColor(c lipgloss.Color, f float64) lipgloss.Color {
+	v, err := strconv.ParseUint(string(c)[1:], 16, 32)
+	if err != nil {
+		return c
+	}
+	r := int(float64(v>>16&0xFF) * f)
+	g := int(float64(v>>8&0xFF) * f)
+	b := int(float64(v&0xFF) * f)
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", r, g, b))
+}
+
+// confettiSprites turns the particles inside width into sprites for
+// overlay. Particles dim over the last part of their life and shrink to
+// a dot.
+func confettiSprites(ps []particle, width int, g glyphSet) []sprite {
+	sprites := make([]sprite, 0, len(ps))
+	for _, p := range ps {
+		if p.x < 0 || int(p.x) >= width-1 {
+			continue
+		}
+		f := float64(p.life) / float64(p.max)
+		glyph := p.glyph
+		style := lipgloss.NewStyle().Foreground(p.color)
+		if f < 0.35 {
+			glyph = g.confettiFade
+			style = style.Foreground(fadeColor(p.color, 0.4+f))
+		}
+		sprites = append(sprites, sprite{x: int(p.x), y: int(p.y), s: style.Render(glyph)})
+	}
+	return sprites
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- DEEP LINKS ---
+
+// Visitors can name a page on the ssh command line, as a path or just a
+// slug: `ssh -t host pathhelm`, `ssh -t host /projects/zenroute` or
+// `ssh -t host help`. The session then starts on that page, without the
+// splash.
+
+// Most close matches offered on the not-found page
+const notFoundMatches = 3
+
+// linkPath turns what was typed after the host into a path.
+func linkPath(args []string) string {
+	arg := strings.TrimSpace(strings.Join(args, " "))
+	if arg == "" {
+		return ""
+	}
+	if strings.HasPrefix(arg, "/") {
+		return strings.ToLower(arg)
+	}
+	slug := strings.ToLower(arg)
+	for i, item := range items {
+		if item.Slug == slug {
+			return itemPath(i)
+		}
+	}
+	return "/" + slug
+}
+
+// deepLink starts the session on the page the command line asked for.
+// Pages that don't exist start on the not-found page, with home behind
+// both so esc leads somewhere.
+func (m model) deepLink(args []string) model {
+	path := linkPath(args)
+	if path == "" || path == pathSplash {
+		return m
+	}
+	m.nav = newRouter(pathHome)
+	m.splashDone = true
+	return m.openPath(path)
+}
+
+// openPath goes to path. Pages with state of their own are set up the
+// way their keys do it.
+func (m model) openPath(path string) model {
+	switch path {
+	case pathEffects:
+		return m.openEffect(newEffects()[0].Name())
+	case pathTyping:
+		m.typing = newTypingTest()
+	case pathGuestbook:
+		return m.openGuestbook()
+	case pathQR:
+		return m.openQR()
+	case pathShell:
+		return m.openShell()
+	case pathAsk:
+		return m.openFAQ()
+	}
+	return m.navigate(path)
+}
+
+// linkedMsg is sent once when a session starts past the splash.
+type linkedMsg struct{}
+
+// arriveLinked does what reaching a page normally does, for a session
+// that started on it.
+func (m model) arriveLinked() (model, tea.Cmd) {
+	reveal := m.bootReveal()
+	if m.page() != routeItem {
+		return m, reveal
+	}
+	m, cmd := m.markViewed(items[m.cursor])
+	return m, tea.Batch(reveal, cmd)
+}
+
+// pagePaths is every path there is to offer: the pages and the items.
+func pagePaths() []string {
+	var paths []string
+	for path := range routes {
+		if path != pathSplash && path != pathQR {
+			paths = append(paths, path)
+		}
+	}
+	for i := range items {
+		paths = append(paths, itemPath(i))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// closeMatches finds the paths nearest to one that doesn't exist, judged
+// by the edit distance between their last parts.
+func closeMatches(path string) []string {
+	want := lastSegment(path)
+	type match struct {
+		path string
+		dist int
+	}
+	var matches []match
+	for _, p := range pagePaths() {
+		have := lastSegment(p)
+		d := editDistance(want, have)
+		if strings.Contains(have, want) || strings.Contains(want, have) {
+			d = 0
+		}
+		// Allow about one typo in three letters
+		if have != "" && d <= (len(want)+2)/3 {
+			matches = append(matches, match{p, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].dist < matches[j].dist })
+	var paths []string
+	for i := 0; i < len(matches) && i < notFoundMatches; i++ {
+		paths = append(paths, matches[i].path)
+	}
+	return paths
+}
+
+func lastSegment(path string) string {
+	path = strings.Trim(path, "/")
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// openMatch follows close match n on the not-found page.
+func (m model) openMatch(n int) (model, tea.Cmd) {
+	matches := closeMatches(m.nav.path())
+	if n >= len(matches) {
+		return m, nil
+	}
+	m = m.openPath(matches[n])
+	if m.page() == routeItem {
+		return m.markViewed(items[m.cursor])
+	}
+	return m, nil
+}
+
+func (m model) renderNotFound(width int) string {
+	var b strings.Builder
+	b.WriteString(centerText(sectionStyle.Render("▸ NOT FOUND"), width))
+	b.WriteString("\n\n")
+	b.WriteString(centerText(socialText.Render("There's nothing at ")+titleStyle.Render(printable(m.nav.path())), width))
+	b.WriteString("\n\n")
+
+	matches := closeMatches(m.nav.path())
+	if len(matches) == 0 {
+		b.WriteString(centerText(itemNormal.Render("No close matches. These are the pages there are:"), width))
+		b.WriteString("\n\n")
+		for _, p := range pagePaths() {
+			b.WriteString(centerText(socialText.Render(p), width))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(centerText(itemNormal.Render("Did you mean"), width))
+		b.WriteString("\n\n")
+		for i, p := range matches {
+			line := tagStyle.Render(fmt.Sprint(i+1)) + "  " + socialText.Render(p)
+			b.WriteString(centerText(line, width))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	hints := m.keys.hints(false, hint{actBack, "home"})
+	switch len(matches) {
+	case 0:
+	case 1:
+		hints = "1 open · " + hints
+	default:
+		hints = fmt.Sprintf("1-%d open · ", len(matches)) + hints
+	}
+	b.WriteString(centerText(hintStyle.Render(hints), width))
+	return b.String()
+}
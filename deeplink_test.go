@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLinkPath(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"  "}, ""},
+		{[]string{"help"}, "/help"},
+		{[]string{"Help"}, "/help"},
+		{[]string{"pathhelm"}, "/projects/pathhelm"},
+		{[]string{"PathHelm"}, "/projects/pathhelm"},
+		{[]string{"about"}, "/about"},
+		{[]string{"/Projects/ZenRoute"}, "/projects/zenroute"},
+		{[]string{"/nowhere"}, "/nowhere"},
+		{[]string{"no", "where"}, "/no where"},
+	}
+	for _, tt := range tests {
+		if got := linkPath(tt.args); got != tt.want {
+			t.Errorf("linkPath(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCloseMatches(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/halp", []string{"/help"}},
+		{"/typ", []string{"/typing"}},
+		{"/projects/pathelm", []string{"/projects/pathhelm"}},
+		{"/zenrout", []string{"/projects/zenroute"}},
+		{"/chat", []string{"/projects/chat-server"}},
+		{"/qr", nil}, // there, but not a page to offer
+		{"/xyzzyplugh", nil},
+	}
+	for _, tt := range tests {
+		if got := closeMatches(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closeMatches(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNotFoundStripsEscapes(t *testing.T) {
+	m := initialModel()
+	m.nav = newRouter(linkPath([]string{"/\x1b[2J\x1b]0;pwned\x07oops"}))
+	for _, c := range m.breadcrumbs() {
+		if c.label != printable(c.label) {
+			t.Errorf("breadcrumb %q has control characters", c.label)
+		}
+	}
+	page := m.renderNotFound(72)
+	for _, esc := range []string{"\x1b[2J", "\x1b]0;", "\x07"} {
+		if strings.Contains(page, esc) {
+			t.Errorf("not-found page echoes %q", esc)
+		}
+	}
+}
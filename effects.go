@@ -0,0 +1,425 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// --- SCREEN EFFECTS ---
+
+// Effect is a full screen animation for the gallery. Init is called with
+// the drawing area on first use and again on every resize, Step once per
+// tick, and Render whenever the screen is drawn, in the colors the
+// session's terminal can show.
+type Effect interface {
+	Name() string
+	Init(width, height int)
+	Step()
+	Render(p termenv.Profile) string
+}
+
+// newEffects returns fresh instances of every effect, in gallery order.
+func newEffects() []Effect {
+	return []Effect{
+		&matrixRain{},
+		&starfield{},
+		&fireEffect{},
+		&lifeEffect{},
+		&plasmaEffect{},
+	}
+}
+
+// renderCells draws a grid where cell returns the glyph and palette index
+// for each position, or a negative index for a blank, in the colors p can
+// show. Like the matrix renderer it only emits an escape sequence when the
+// color changes.
+func renderCells(p termenv.Profile, width, height int, palette []string, cell func(x, y int) (rune, int)) string {
+	sgr := make([]string, len(palette))
+	for i, c := range palette {
+		if seq := p.Color(c).Sequence(false); seq != "" {
+			sgr[i] = termenv.CSI + seq + "m"
+		}
+	}
+
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		cur, blanks := -1, 0
+		for x := 0; x < width; x++ {
+			r, c := cell(x, y)
+			if c < 0 {
+				blanks++
+				continue
+			}
+			b.WriteString(strings.Repeat(" ", blanks))
+			blanks = 0
+			if c != cur {
+				b.WriteString(sgr[c])
+				cur = c
+			}
+			b.WriteRune(r)
+		}
+		// Trailing blanks are left out entirely
+		if cur >= 0 {
+			b.WriteString(termenv.CSI + termenv.ResetSeq + "m")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Starfield
+
+var starPalette = []string{"#444444", "#888888", "#CCCCCC", "#FFFFFF"}
+
+type star struct{ x, y, z float64 }
+
+type starfield struct {
+	width, height int
+	stars         []star
+}
+
+func (s *starfield) Name() string { return "starfield" }
+
+func (s *starfield) Init(width, height int) {
+	s.width, s.height = width, height
+	n := width * height / 20
+	for len(s.stars) < n {
+		st := newStar()
+		st.z = 0.1 + rand.Float64()*0.9
+		s.stars = append(s.stars, st)
+	}
+	s.stars = s.stars[:n]
+}
+
+func newStar() star {
+	return star{x: rand.Float64()*2 - 1, y: rand.Float64()*2 - 1, z: 1}
+}
+
+// project maps a star onto the screen. Cells are about twice as tall as
+// they are wide, so y is squashed to keep the field round.
+func (s *starfield) project(st star) (int, int) {
+	cx, cy := float64(s.width)/2, float64(s.height)/2
+	return int(cx + st.x/st.z*cx), int(cy + st.y/st.z*cy)
+}
+
+func (s *starfield) Step() {
+	for i := range s.stars {
+		s.stars[i].z -= 0.015
+		x, y := s.project(s.stars[i])
+		if s.stars[i].z <= 0.02 || x < 0 || y < 0 || x >= s.width || y >= s.height {
+			s.stars[i] = newStar()
+		}
+	}
+}
+
+func (s *starfield) Render(profile termenv.Profile) string {
+	glyphs := make([]rune, s.width*s.height)
+	colors := make([]int, s.width*s.height)
+	for i := range colors {
+		colors[i] = -1
+	}
+	for _, st := range s.stars {
+		x, y := s.project(st)
+		if x < 0 || y < 0 || x >= s.width || y >= s.height {
+			continue
+		}
+		// Closer stars are brighter and bigger
+		lvl := int((1 - st.z) * float64(len(starPalette)))
+		if lvl >= len(starPalette) {
+			lvl = len(starPalette) - 1
+		}
+		i := y*s.width + x
+		if lvl > colors[i] {
+			colors[i] = lvl
+			glyphs[i] = []rune(".·*✦")[lvl]
+		}
+	}
+	return renderCells(profile, s.width, s.height, starPalette, func(x, y int) (rune, int) {
+		i := y*s.width + x
+		return glyphs[i], colors[i]
+	})
+}
+
+// Fire, the classic demoscene/Doom style heat map
+
+const fireMaxHeat = 36
+
+var firePalette = []string{"#3A0000", "#7A0A00", "#B81D00", "#E63900", "#FF6B00", "#FF9A00", "#FFC800", "#FFF3B0"}
+
+var fireGlyphs = []rune(".:*sS#$@")
+
+type fireEffect struct {
+	width, height int
+	heat          []int
+}
+
+func (f *fireEffect) Name() string { return "fire" }
+
+func (f *fireEffect) Init(width, height int) {
+	if width == f.width && height == f.height {
+		return
+	}
+	f.width, f.height = width, height
+	f.heat = make([]int, width*height)
+}
+
+func (f *fireEffect) Step() {
+	if f.height == 0 {
+		return
+	}
+	// Flames should reach about two thirds of the way up at any height
+	decay := 2*fireMaxHeat*3/(2*f.height) + 1
+	bottom := (f.height - 1) * f.width
+	for x := 0; x < f.width; x++ {
+		f.heat[bottom+x] = fireMaxHeat - rand.Intn(6)
+	}
+	for y := 0; y < f.height-1; y++ {
+		for x := 0; x < f.width; x++ {
+			src := f.heat[(y+1)*f.width+x]
+			dx := x + rand.Intn(3) - 1
+			if dx < 0 || dx >= f.width {
+				dx = x
+			}
+			h := src - rand.Intn(decay+1)
+			if h < 0 {
+				h = 0
+			}
+			f.heat[y*f.width+dx] = h
+		}
+	}
+}
+
+func (f *fireEffect) Render(profile termenv.Profile) string {
+	return renderCells(profile, f.width, f.height, firePalette, func(x, y int) (rune, int) {
+		h := f.heat[y*f.width+x]
+		if h <= 2 {
+			return ' ', -1
+		}
+		i := (h - 1) * len(firePalette) / fireMaxHeat
+		if i >= len(firePalette) {
+			i = len(firePalette) - 1
+		}
+		return fireGlyphs[i], i
+	})
+}
+
+// Conway's Game of Life on a torus
+
+var lifePalette = []string{"#00FFAA", "#00C8A0", "#008C78", "#005A50"}
+
+type lifeEffect struct {
+	width, height int
+	ages          []int // 0 is dead, otherwise generations alive
+	next          []int
+	tick          int
+	generation    int
+	stale         int
+	lastPop       int
+}
+
+func (l *lifeEffect) Name() string { return "life" }
+
+func (l *lifeEffect) Init(width, height int) {
+	if width == l.width && height == l.height {
+		return
+	}
+	l.width, l.height = width, height
+	l.seed()
+}
+
+func (l *lifeEffect) seed() {
+	l.ages = make([]int, l.width*l.height)
+	l.next = make([]int, l.width*l.height)
+	for i := range l.ages {
+		if rand.Intn(4) == 0 {
+			l.ages[i] = 1
+		}
+	}
+	l.generation, l.stale, l.lastPop = 0, 0, 0
+}
+
+func (l *lifeEffect) Step() {
+	// A generation every third tick is slow enough to follow
+	l.tick++
+	if l.tick%3 != 0 || l.width == 0 || l.height == 0 {
+		return
+	}
+	pop := 0
+	for y := 0; y < l.height; y++ {
+		for x := 0; x < l.width; x++ {
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx == 0 && dy == 0 {
+						continue
+					}
+					nx := (x + dx + l.width) % l.width
+					ny := (y + dy + l.height) % l.height
+					if l.ages[ny*l.width+nx] > 0 {
+						n++
+					}
+				}
+			}
+			i := y*l.width + x
+			switch {
+			case l.ages[i] > 0 && (n == 2 || n == 3):
+				l.next[i] = l.ages[i] + 1
+			case l.ages[i] == 0 && n == 3:
+				l.next[i] = 1
+			default:
+				l.next[i] = 0
+			}
+			if l.next[i] > 0 {
+				pop++
+			}
+		}
+	}
+	l.ages, l.next = l.next, l.ages
+	l.generation++
+
+	// Start over once the board has settled into still lifes and blinkers
+	if pop == l.lastPop {
+		l.stale++
+	} else {
+		l.stale = 0
+	}
+	l.lastPop = pop
+	if l.stale > 40 || l.generation > 1000 || pop == 0 {
+		l.seed()
+	}
+}
+
+func (l *lifeEffect) Render(profile termenv.Profile) string {
+	return renderCells(profile, l.width, l.height, lifePalette, func(x, y int) (rune, int) {
+		age := l.ages[y*l.width+x]
+		if age == 0 {
+			return ' ', -1
+		}
+		i := (age - 1) / 3
+		if i >= len(lifePalette) {
+			i = len(lifePalette) - 1
+		}
+		return '█', i
+	})
+}
+
+// Plasma
+
+var plasmaPalette = func() []string {
+	// A loop through the accent oranges into purples and blues and back
+	var p []string
+	for i := 0; i < 16; i++ {
+		t := float64(i) / 16 * 2 * math.Pi
+		r := int(128 + 127*math.Sin(t))
+		g := int(90 + 80*math.Sin(t+2*math.Pi/3))
+		b := int(128 + 127*math.Sin(t+4*math.Pi/3))
+		p = append(p, fmt.Sprintf("#%02X%02X%02X", r, g, b))
+	}
+	return p
+}()
+
+var plasmaGlyphs = []rune(".:-=+*#%@")
+
+type plasmaEffect struct {
+	width, height int
+	t             float64
+}
+
+func (p *plasmaEffect) Name() string { return "plasma" }
+
+func (p *plasmaEffect) Init(width, height int) {
+	p.width, p.height = width, height
+}
+
+func (p *plasmaEffect) Step() {
+	p.t += 0.08
+}
+
+func (p *plasmaEffect) Render(profile termenv.Profile) string {
+	return renderCells(profile, p.width, p.height, plasmaPalette, func(x, y int) (rune, int) {
+		fx, fy := float64(x), float64(y)*2
+		v := math.Sin(fx/9+p.t) +
+			math.Sin(fy/7+p.t/2) +
+			math.Sin((fx+fy)/12+p.t) +
+			math.Sin(math.Sqrt(fx*fx+fy*fy)/9-p.t)
+		// v is in [-4, 4]; map it onto the palette and glyph ramp
+		n := (v + 4) / 8
+		c := int(n*float64(len(plasmaPalette))) % len(plasmaPalette)
+		g := int(n * float64(len(plasmaGlyphs)))
+		if g >= len(plasmaGlyphs) {
+			g = len(plasmaGlyphs) - 1
+		}
+		return plasmaGlyphs[g], c
+	})
+}
+
+// Gallery
+
+// effectSize is the drawing area: the whole screen minus the hint line.
+func (m model) effectSize() (int, int) {
+	h := m.height - 1
+	if h < 1 {
+		h = 1
+	}
+	return m.width, h
+}
+
+// openEffect switches to the gallery showing the named effect.
+func (m model) openEffect(name string) model {
+	if m.effects == nil {
+		m.effects = newEffects()
+	}
+	for i, e := range m.effects {
+		if e.Name() == name {
+			m.effectIndex = i
+		}
+	}
+	m.effects[m.effectIndex].Init(m.effectSize())
+	return m.navigate(pathEffects)
+}
+
+func (m model) updateEffects(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	switch m.keys.action(msg.String()) {
+	case actBack, actQuit, actPrev:
+		m = m.back()
+	case actForward:
+		m = m.forward()
+	case actLeft:
+		m.effectIndex = (m.effectIndex + len(m.effects) - 1) % len(m.effects)
+		m.effects[m.effectIndex].Init(m.effectSize())
+	case actRight, actNext:
+		m.effectIndex = (m.effectIndex + 1) % len(m.effects)
+		m.effects[m.effectIndex].Init(m.effectSize())
+	}
+	return m, nil
+}
+
+func (m model) renderEffects() string {
+	var b strings.Builder
+	current := m.effects[m.effectIndex]
+	b.WriteString(current.Render(m.colors))
+
+	var names []string
+	for i, e := range m.effects {
+		if i == m.effectIndex {
+			names = append(names, itemSelected.UnsetPadding().Render(e.Name()))
+		} else {
+			names = append(names, hintStyle.Render(e.Name()))
+		}
+	}
+	hints := strings.Join(names, hintStyle.Render(" · ")) +
+		hintStyle.Render("   "+m.keys.hints(false, hint{actLeft, "switch"}, hint{actBack, "back"}))
+	if m.toast != "" {
+		hints = toastStyle.Render(m.toast)
+	}
+	b.WriteString(centerText(hints, m.width))
+	return b.String()
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- EASTER EGG REGISTRY ---
+
+// eggEvent is what a trigger gets to look at. Key presses fill in key and
+// typed; the once-a-second clock leaves key empty.
+type eggEvent struct {
+	key   string
+	typed string
+	now   time.Time
+	idle  time.Duration
+}
+
+// eggState is the per-session progress of every trigger, keyed by egg ID.
+type eggState struct {
+	progress map[string]int
+}
+
+func newEggState() eggState {
+	return eggState{progress: map[string]int{}}
+}
+
+// EasterEgg is a secret that applies an effect when its trigger matches.
+// The ID is stable and used to track which eggs a visitor has found.
+// Hints run from vague to giving it away.
+type EasterEgg interface {
+	ID() string
+	Hints() []string
+	Match(ev eggEvent, st eggState) bool
+	Apply(m model) (model, tea.Cmd)
+}
+
+type eggTrigger interface {
+	match(id string, ev eggEvent, st eggState) bool
+}
+
+type eggEffect interface {
+	apply(m model) (model, tea.Cmd)
+}
+
+// Triggers
+
+type sequenceTrigger struct{ keys []string }
+
+func (t sequenceTrigger) match(id string, ev eggEvent, st eggState) bool {
+	if ev.key == "" {
+		return false
+	}
+	i := st.progress[id]
+	if ev.key == t.keys[i] {
+		i++
+	} else {
+		// Fall back to the longest prefix that still ends with this key,
+		// so "up up up down down ..." keeps counting.
+		i = t.fallback(i, ev.key)
+	}
+	if i == len(t.keys) {
+		st.progress[id] = 0
+		return true
+	}
+	st.progress[id] = i
+	return false
+}
+
+func (t sequenceTrigger) fallback(matched int, key string) int {
+	seen := append(append([]string{}, t.keys[:matched]...), key)
+	for n := matched; n > 0; n-- {
+		tail := seen[len(seen)-n:]
+		ok := true
+		for j := range tail {
+			if tail[j] != t.keys[j] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return n
+		}
+	}
+	return 0
+}
+
+type wordTrigger struct{ word string }
+
+func (t wordTrigger) match(id string, ev eggEvent, st eggState) bool {
+	return ev.key != "" && strings.HasSuffix(ev.typed, t.word)
+}
+
+// hoursTrigger fires once per session, on the clock, when the server's
+// local time is in [from, to).
+type hoursTrigger struct{ from, to int }
+
+func (t hoursTrigger) match(id string, ev eggEvent, st eggState) bool {
+	if ev.key != "" || st.progress[id] > 0 {
+		return false
+	}
+	h := ev.now.Hour()
+	if h >= t.from && h < t.to {
+		st.progress[id] = 1
+		return true
+	}
+	return false
+}
+
+// idleTrigger fires once per idle stretch; any key re-arms it.
+type idleTrigger struct{ after time.Duration }
+
+func (t idleTrigger) match(id string, ev eggEvent, st eggState) bool {
+	if ev.key != "" {
+		st.progress[id] = 0
+		return false
+	}
+	if st.progress[id] > 0 || ev.idle < t.after {
+		return false
+	}
+	st.progress[id] = 1
+	return true
+}
+
+// Effects
+
+type quoteEffect struct {
+	text   string
+	random bool
+}
+
+func (e quoteEffect) apply(m model) (model, tea.Cmd) {
+	m.showQuote = true
+	m.currentQuote = e.text
+	if e.random {
+		m.currentQuote = quotes[rand.Intn(len(quotes))]
+	}
+	m.easterEggTimer = 0
+	return m, m.tick()
+}
+
+type viewEffect struct{ open func(m model) model }
+
+func (e viewEffect) apply(m model) (model, tea.Cmd) {
+	m = e.open(m)
+	return m, m.tick()
+}
+
+type animationEffect struct{ name string }
+
+func (e animationEffect) apply(m model) (model, tea.Cmd) {
+	switch e.name {
+	case "confetti":
+		x, y := m.confettiOrigin()
+		m.confetti = append(m.confetti, burstConfetti(x, y, m.glyphs)...)
+	}
+	return m, m.tick()
+}
+
+// specEgg is an EasterEgg built from an eggSpec in the content section.
+type specEgg struct {
+	id      string
+	hints   []string
+	trigger eggTrigger
+	effects []eggEffect
+}
+
+func (e specEgg) ID() string { return e.id }
+
+func (e specEgg) Hints() []string { return e.hints }
+
+func (e specEgg) Match(ev eggEvent, st eggState) bool {
+	return e.trigger.match(e.id, ev, st)
+}
+
+func (e specEgg) Apply(m model) (model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, eff := range e.effects {
+		var cmd tea.Cmd
+		m, cmd = eff.apply(m)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+var eggViews = map[string]func(m model) model{
+	"matrix":    func(m model) model { return m.openEffect("matrix") },
+	"starfield": func(m model) model { return m.openEffect("starfield") },
+	"fire":      func(m model) model { return m.openEffect("fire") },
+	"life":      func(m model) model { return m.openEffect("life") },
+	"plasma":    func(m model) model { return m.openEffect("plasma") },
+	"help":      func(m model) model { return m.navigate(pathHelp) },
+	"typing":    func(m model) model { m.typing = newTypingTest(); return m.navigate(pathTyping) },
+}
+
+var eggAnimations = map[string]bool{
+	"confetti": true,
+}
+
+func newSpecEgg(s eggSpec) (EasterEgg, error) {
+	e := specEgg{id: s.ID, hints: s.Hints}
+	if s.ID == "" {
+		return nil, fmt.Errorf("easter egg without an ID")
+	}
+
+	var triggers []eggTrigger
+	if len(s.Keys) > 0 {
+		triggers = append(triggers, sequenceTrigger{keys: s.Keys})
+	}
+	if s.Word != "" {
+		triggers = append(triggers, wordTrigger{word: s.Word})
+	}
+	if s.Hours != nil {
+		if len(s.Hours) != 2 || s.Hours[0] >= s.Hours[1] {
+			return nil, fmt.Errorf("easter egg %q: hours must be [from, to)", s.ID)
+		}
+		triggers = append(triggers, hoursTrigger{from: s.Hours[0], to: s.Hours[1]})
+	}
+	if s.Idle > 0 {
+		triggers = append(triggers, idleTrigger{after: s.Idle})
+	}
+	if len(triggers) != 1 {
+		return nil, fmt.Errorf("easter egg %q: needs exactly one trigger, has %d", s.ID, len(triggers))
+	}
+	e.trigger = triggers[0]
+
+	if s.Quote != "" || s.RandomQuote {
+		e.effects = append(e.effects, quoteEffect{text: s.Quote, random: s.RandomQuote})
+	}
+	if s.View != "" {
+		open, ok := eggViews[s.View]
+		if !ok {
+			return nil, fmt.Errorf("easter egg %q: unknown view %q", s.ID, s.View)
+		}
+		e.effects = append(e.effects, viewEffect{open: open})
+	}
+	if s.Animation != "" {
+		if !eggAnimations[s.Animation] {
+			return nil, fmt.Errorf("easter egg %q: unknown animation %q", s.ID, s.Animation)
+		}
+		e.effects = append(e.effects, animationEffect{name: s.Animation})
+	}
+	if len(e.effects) == 0 {
+		return nil, fmt.Errorf("easter egg %q: has no effect", s.ID)
+	}
+	return e, nil
+}
+
+type eggRegistry struct {
+	eggs []EasterEgg
+	byID map[string]EasterEgg
+}
+
+func (r *eggRegistry) Register(e EasterEgg) error {
+	if r.byID == nil {
+		r.byID = map[string]EasterEgg{}
+	}
+	if _, dup := r.byID[e.ID()]; dup {
+		return fmt.Errorf("easter egg %q registered twice", e.ID())
+	}
+	r.byID[e.ID()] = e
+	r.eggs = append(r.eggs, e)
+	return nil
+}
+
+// Match offers ev to every egg, so all sequences advance, and returns the
+// first one that fired.
+func (r *eggRegistry) Match(ev eggEvent, st eggState) EasterEgg {
+	var fired EasterEgg
+	for _, e := range r.eggs {
+		if e.Match(ev, st) && fired == nil {
+			fired = e
+		}
+	}
+	return fired
+}
+
+func mustEggRegistry(specs []eggSpec) *eggRegistry {
+	r := &eggRegistry{}
+	for _, s := range specs {
+		e, err := newSpecEgg(s)
+		if err == nil {
+			err = r.Register(e)
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	return r
+}
+
+var easterEggRegistry = mustEggRegistry(easterEggs)
+
+// fireEgg applies egg and unlocks its achievement.
+func (m model) fireEgg(egg EasterEgg) (model, tea.Cmd) {
+	m.typedBuffer = ""
+	m, cmd := egg.Apply(m)
+	m, unlockCmd := m.unlock(egg.ID())
+	return m, tea.Batch(cmd, unlockCmd)
+}
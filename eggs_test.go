@@ -0,0 +1,142 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSequenceTrigger(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  string
+		input string
+		fires []int // indexes into input where the trigger matches
+	}{
+		{"straight", "a b c", "a b c", []int{2}},
+		{"twice", "a b", "a b a b", []int{1, 3}},
+		{"wrong key resets", "a b c", "a b x c", nil},
+		{"wrong key starts over", "a b c", "a x a b c", []int{4}},
+		{"repeated first key", "a a b", "a a a b", []int{3}},
+		{"overlapping prefix", "a b a c", "a b a b a c", []int{5}},
+		{"fallback to a shorter prefix", "a a b a a c", "a a b a a b a a c", []int{8}},
+		{"first key alone", "x", "x y x", []int{0, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trig := sequenceTrigger{keys: strings.Fields(tt.keys)}
+			st := newEggState()
+			var fires []int
+			for i, k := range strings.Fields(tt.input) {
+				if trig.match("egg", eggEvent{key: k}, st) {
+					fires = append(fires, i)
+				}
+			}
+			if !reflect.DeepEqual(fires, tt.fires) {
+				t.Errorf("%q on %q fires at %v, want %v", tt.keys, tt.input, fires, tt.fires)
+			}
+		})
+	}
+}
+
+// The content's eggs, fed key presses the way Update feeds them.
+func TestEggSpecs(t *testing.T) {
+	night := time.Date(2026, 1, 1, 2, 0, 0, 0, time.Local)
+	noon := time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local)
+	konami := strings.Join(konamiCode, " ")
+	tests := []struct {
+		name  string
+		keys  string // pressed one by one
+		now   time.Time
+		fired []string
+	}{
+		{"konami", konami, noon, []string{"konami"}},
+		{"konami after extra ups", "up up up " + konami[len("up up "):], noon, []string{"konami"}},
+		{"konami after a false start", "up up down x " + konami, noon, []string{"konami"}},
+		{"konami broken partway", "up up down down left right x left right b a", noon, nil},
+		{"matrix", "m", noon, []string{"matrix"}},
+		{"hello", "h e l l o", noon, []string{"hello"}},
+		{"hire", "h i r e", noon, []string{"hire"}},
+		{"word with a typo", "h e l p l o", noon, nil},
+		{"word in a longer run", "o h h e l l o", noon, []string{"hello"}},
+		{"surprise and confetti", "s c", noon, []string{"surprise", "confetti"}},
+		{"night owl waits for the clock", "x y", night, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newEggState()
+			typed := ""
+			var fired []string
+			for _, k := range strings.Fields(tt.keys) {
+				if len(k) == 1 {
+					typed += k
+				}
+				if egg := easterEggRegistry.Match(eggEvent{key: k, typed: typed, now: tt.now}, st); egg != nil {
+					fired = append(fired, egg.ID())
+					typed = ""
+				}
+			}
+			if !reflect.DeepEqual(fired, tt.fired) {
+				t.Errorf("%q fired %v, want %v", tt.keys, fired, tt.fired)
+			}
+		})
+	}
+}
+
+func TestEggClock(t *testing.T) {
+	night := time.Date(2026, 1, 1, 2, 0, 0, 0, time.Local)
+	noon := time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name  string
+		ticks []eggEvent
+		fired []string
+	}{
+		{"night owl", []eggEvent{{now: night}}, []string{"night-owl"}},
+		{"night owl once a session", []eggEvent{{now: night}, {now: night}}, []string{"night-owl"}},
+		{"not at noon", []eggEvent{{now: noon}}, nil},
+		{"idle", []eggEvent{{now: noon, idle: time.Minute}}, []string{"idle"}},
+		{"idle once a stretch", []eggEvent{{now: noon, idle: time.Minute}, {now: noon, idle: 2 * time.Minute}}, []string{"idle"}},
+		{"a key re-arms idle", []eggEvent{
+			{now: noon, idle: time.Minute},
+			{key: "x", now: noon},
+			{now: noon, idle: time.Minute},
+		}, []string{"idle", "idle"}},
+		{"not idle long enough", []eggEvent{{now: noon, idle: time.Second}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newEggState()
+			var fired []string
+			for _, ev := range tt.ticks {
+				if egg := easterEggRegistry.Match(ev, st); egg != nil {
+					fired = append(fired, egg.ID())
+				}
+			}
+			if !reflect.DeepEqual(fired, tt.fired) {
+				t.Errorf("fired %v, want %v", fired, tt.fired)
+			}
+		})
+	}
+}
+
+func TestNewSpecEggErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec eggSpec
+		err  string
+	}{
+		{"no ID", eggSpec{Keys: []string{"x"}, Quote: "hi"}, "without an ID"},
+		{"no trigger", eggSpec{ID: "e", Quote: "hi"}, "needs exactly one trigger, has 0"},
+		{"two triggers", eggSpec{ID: "e", Keys: []string{"x"}, Word: "xy", Quote: "hi"}, "needs exactly one trigger, has 2"},
+		{"backwards hours", eggSpec{ID: "e", Hours: []int{5, 1}, Quote: "hi"}, "hours must be [from, to)"},
+		{"unknown view", eggSpec{ID: "e", Word: "xy", View: "nowhere"}, `unknown view "nowhere"`},
+		{"unknown animation", eggSpec{ID: "e", Word: "xy", Animation: "fireworks"}, `unknown animation "fireworks"`},
+		{"no effect", eggSpec{ID: "e", Word: "xy"}, "has no effect"},
+	}
+	for _, tt := range tests {
+		if _, err := newSpecEgg(tt.spec); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: newSpecEgg: %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- ASK ME ANYTHING ---
+
+// Free-form questions are matched against the FAQs in the content by
+// TF-IDF. Each way an FAQ is asked is compared with the question by
+// cosine similarity, and the closest answers; the answer text counts
+// too, for less. All of it is worked out here, offline.
+
+const (
+	faqInputLimit = 100
+	// Below this similarity the bot admits it doesn't know
+	faqMinConfidence = 0.3
+	// How much matching an answer's words counts next to matching a
+	// way of asking
+	faqAnswerWeight = 0.5
+	faqFollowUps    = 3
+	faqAnswerWidth  = 56
+)
+
+// Words too common to tell questions apart
+var faqStopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`a about an and are as at be been but by can could did do does for from
+		had has have he how i if in is it its me my of on or she so tell that the their there they this to
+		was we were what when where which who why will with would you your yours i'm you're it's what's`) {
+		faqStopwords[w] = true
+	}
+}
+
+// Endings cut off words, so "hiring", "hired" and "hire" all count as
+// the same word. Longest first; only one goes.
+var faqSuffixes = []string{"ation", "ing", "ed", "es", "s", "e"}
+
+// faqTerms splits text into the words that count, lowercased and cut
+// down to their stems.
+func faqTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	var terms []string
+	for _, w := range words {
+		w = strings.Trim(w, "'")
+		if w == "" || faqStopwords[w] {
+			continue
+		}
+		terms = append(terms, faqStem(w))
+	}
+	return terms
+}
+
+func faqStem(w string) string {
+	for _, suffix := range faqSuffixes {
+		if len(w)-len(suffix) >= 3 && strings.HasSuffix(w, suffix) && !strings.HasSuffix(w, "ss") {
+			if suffix == "ation" {
+				return strings.TrimSuffix(w, "ion")
+			}
+			return strings.TrimSuffix(w, suffix)
+		}
+	}
+	return w
+}
+
+type faqVector map[string]float64
+
+func (v faqVector) norm() float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+// faqIndex is the FAQs as TF-IDF vectors, one for each way of asking
+// and one for the answer.
+type faqIndex struct {
+	faqs    []FAQ
+	byID    map[string]int
+	idf     map[string]float64
+	unknown float64 // idf of a word no FAQ uses
+	asked   [][]faqVector
+	answers []faqVector
+}
+
+// newFAQIndex builds the index, checking that IDs are unique and
+// follow-ups point at FAQs that exist.
+func newFAQIndex(faqs []FAQ) (*faqIndex, error) {
+	ix := &faqIndex{faqs: faqs, byID: map[string]int{}, idf: map[string]float64{}}
+	df := map[string]int{}
+	for i, f := range faqs {
+		if f.ID == "" || f.Question == "" || f.Answer == "" {
+			return nil, fmt.Errorf("faq %d: needs an ID, a question and an answer", i)
+		}
+		if _, dup := ix.byID[f.ID]; dup {
+			return nil, fmt.Errorf("faq %q listed twice", f.ID)
+		}
+		ix.byID[f.ID] = i
+		// An FAQ is one document when counting which words are rare
+		all := strings.Join(append([]string{f.Question, f.Answer}, f.Also...), " ")
+		seen := map[string]bool{}
+		for _, t := range faqTerms(all) {
+			if !seen[t] {
+				seen[t] = true
+				df[t]++
+			}
+		}
+	}
+	for _, f := range faqs {
+		for _, id := range f.Follow {
+			if _, ok := ix.byID[id]; !ok {
+				return nil, fmt.Errorf("faq %q: follow-up %q doesn't exist", f.ID, id)
+			}
+		}
+	}
+
+	n := float64(len(faqs))
+	for t, d := range df {
+		ix.idf[t] = math.Log((n+1)/float64(d+1)) + 1
+	}
+	ix.unknown = math.Log(n+1) + 1
+	for _, f := range faqs {
+		var asked []faqVector
+		for _, q := range append([]string{f.Question}, f.Also...) {
+			asked = append(asked, ix.vector(faqTerms(q)))
+		}
+		ix.asked = append(ix.asked, asked)
+		ix.answers = append(ix.answers, ix.vector(faqTerms(f.Answer)))
+	}
+	return ix, nil
+}
+
+func mustFAQIndex(faqs []FAQ) *faqIndex {
+	ix, err := newFAQIndex(faqs)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return ix
+}
+
+var faqBot = mustFAQIndex(faqs)
+
+// vector weighs terms by how often they come up here and how rare they
+// are across the FAQs. Words no FAQ uses still count, against the
+// match: a question that's mostly about something else isn't answered.
+func (ix *faqIndex) vector(terms []string) faqVector {
+	v := faqVector{}
+	for _, t := range terms {
+		v[t]++
+	}
+	for t, tf := range v {
+		idf, ok := ix.idf[t]
+		if !ok {
+			idf = ix.unknown
+		}
+		v[t] = (1 + math.Log(tf)) * idf
+	}
+	return v
+}
+
+// faqMatch is an answer and how sure the bot is of it, from 0 to 1.
+type faqMatch struct {
+	faq        FAQ
+	confidence float64
+}
+
+// ask finds the FAQs closest to question, best first.
+func (ix *faqIndex) ask(question string) []faqMatch {
+	q := ix.vector(faqTerms(question))
+	qn := q.norm()
+	if qn == 0 {
+		return nil
+	}
+	cosine := func(d faqVector) float64 {
+		dot := 0.0
+		for t, x := range q {
+			dot += x * d[t]
+		}
+		if dot == 0 {
+			return 0
+		}
+		return dot / (qn * d.norm())
+	}
+	var matches []faqMatch
+	for i, asked := range ix.asked {
+		best := faqAnswerWeight * cosine(ix.answers[i])
+		for _, d := range asked {
+			best = max(best, cosine(d))
+		}
+		if best > 0 {
+			matches = append(matches, faqMatch{ix.faqs[i], best})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].confidence > matches[j].confidence })
+	return matches
+}
+
+// faqChat is the ask view: the question last asked, its answer if there
+// was one good enough, and what to ask next.
+type faqChat struct {
+	input    lineInput
+	asked    string
+	answer   *faqMatch
+	followUp []string // questions
+	pick     int      // next follow-up tab fills in
+}
+
+func (m model) openFAQ() model {
+	m.faq = faqChat{input: newLineInput(faqInputLimit)}
+	m.faq.followUp = faqQuestions(faqBot.faqs[:min(faqFollowUps, len(faqBot.faqs))])
+	return m.navigate(pathAsk)
+}
+
+func faqQuestions(fs []FAQ) []string {
+	var qs []string
+	for _, f := range fs {
+		qs = append(qs, f.Question)
+	}
+	return qs
+}
+
+// answer asks what's in the input.
+func (m model) answer() model {
+	question := strings.TrimSpace(m.faq.input.String())
+	if question == "" {
+		return m
+	}
+	m.faq.input.Reset()
+	m.faq.asked = question
+	m.faq.answer = nil
+	m.faq.pick = 0
+
+	matches := faqBot.ask(question)
+	if len(matches) > 0 && matches[0].confidence >= faqMinConfidence {
+		best := matches[0]
+		m.faq.answer = &best
+		var follow []FAQ
+		for _, id := range best.faq.Follow {
+			follow = append(follow, faqBot.faqs[faqBot.byID[id]])
+		}
+		m.faq.followUp = faqQuestions(follow)
+		return m
+	}
+	// Not sure: offer the nearest few instead, or the first few there are
+	var near []FAQ
+	for _, mt := range matches {
+		near = append(near, mt.faq)
+	}
+	if len(near) == 0 {
+		near = faqBot.faqs
+	}
+	m.faq.followUp = faqQuestions(near[:min(faqFollowUps, len(near))])
+	return m
+}
+
+func (m model) updateFAQ(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		return m.back(), nil
+	case tea.KeyEnter:
+		return m.answer(), nil
+	case tea.KeyTab:
+		if len(m.faq.followUp) > 0 {
+			m.faq.input.Set(m.faq.followUp[m.faq.pick%len(m.faq.followUp)])
+			m.faq.pick++
+		}
+		return m, nil
+	}
+	m.faq.input.Update(msg)
+	return m, nil
+}
+
+// confidenceBar shows a confidence as ten cells.
+func confidenceBar(c float64) string {
+	full := int(math.Round(c * 10))
+	return lipgloss.NewStyle().Foreground(green).Render(strings.Repeat("█", full)) +
+		lipgloss.NewStyle().Foreground(dimmed).Render(strings.Repeat("░", 10-full))
+}
+
+func (m model) renderFAQ(contentWidth int) string {
+	var b strings.Builder
+	b.WriteString(centerText(sectionStyle.Render("▸ ASK ME ANYTHING"), contentWidth))
+	b.WriteString("\n\n")
+
+	width := min(faqAnswerWidth, contentWidth-4)
+	text := lipgloss.NewStyle().Foreground(fg).Width(width)
+	asked := lipgloss.NewStyle().Width(width).Render(hintStyle.Render("you asked: ") + socialText.Render(m.faq.asked))
+	var lines []string
+	switch {
+	case m.faq.asked == "":
+		lines = append(lines, socialText.Width(width).Render("Ask about my stack, projects, or whether I'm free to hire. Type a question, or tab through the ones below."))
+	case m.faq.answer != nil:
+		a := m.faq.answer
+		lines = append(lines,
+			asked,
+			"",
+			titleStyle.Render(a.faq.Question),
+			text.Render(a.faq.Answer),
+			"",
+			confidenceBar(a.confidence)+hintStyle.Render(fmt.Sprintf("  %.0f%% sure", a.confidence*100)),
+		)
+	default:
+		lines = append(lines,
+			asked,
+			"",
+			text.Render("I don't have an answer for that one. Try one of these, or get in touch through the links on the home page."),
+		)
+	}
+	if len(m.faq.followUp) > 0 {
+		lines = append(lines, "", hintStyle.Render("You could ask"))
+		for _, q := range m.faq.followUp {
+			lines = append(lines, socialIcon.Render("› ")+socialText.Render(q))
+		}
+	}
+	b.WriteString(centerBlock(lipgloss.JoinVertical(lipgloss.Left, lines...), contentWidth))
+	b.WriteString("\n\n")
+
+	prompt := socialIcon.Render("? ") + m.faq.input.View(lipgloss.NewStyle().Foreground(fg), width-2)
+	b.WriteString(centerBlock(lipgloss.NewStyle().Width(width).Render(prompt), contentWidth))
+	b.WriteString("\n\n")
+
+	hints := hintStyle.Render("enter ask · tab suggest · esc back")
+	b.WriteString(centerText(hints, contentWidth))
+	return b.String()
+}
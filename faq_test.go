@@ -0,0 +1,157 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFAQStem(t *testing.T) {
+	tests := []struct {
+		word, want string
+	}{
+		{"hire", "hir"},
+		{"hired", "hir"},
+		{"hiring", "hir"},
+		{"relocation", "relocat"},
+		{"relocate", "relocat"},
+		{"projects", "project"},
+		{"class", "class"}, // not a plural
+		{"bus", "bus"},     // too short to cut
+		{"go", "go"},
+	}
+	for _, tt := range tests {
+		if got := faqStem(tt.word); got != tt.want {
+			t.Errorf("faqStem(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestFAQTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"What's your stack?", []string{"stack"}},
+		{"Are you HIRING in 2025?", []string{"hir", "2025"}},
+		{"'Quoted' words, and-dashes", []string{"quot", "word", "dash"}},
+		{"Is it?", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := faqTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("faqTerms(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFAQAsk(t *testing.T) {
+	tests := []struct {
+		question string
+		want     string // ID of the confident answer, empty for none
+	}{
+		{"What's your stack?", "stack"},
+		{"which tech stack do you use", "stack"},
+		{"Are you hiring?", "hire"},
+		{"would you relocate", "relocation"},
+		{"how do I reach you", "contact"},
+		{"tell me about pathhelm", "pathhelm"},
+		{"what's the weather like on mars", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		matches := faqBot.ask(tt.question)
+		got := ""
+		if len(matches) > 0 && matches[0].confidence >= faqMinConfidence {
+			got = matches[0].faq.ID
+		}
+		if got != tt.want {
+			t.Errorf("ask(%q) answers %q, want %q (matches %v)", tt.question, got, tt.want, matches)
+		}
+		for i := 1; i < len(matches); i++ {
+			if matches[i].confidence > matches[i-1].confidence {
+				t.Errorf("ask(%q) isn't best first", tt.question)
+			}
+		}
+	}
+}
+
+func TestFAQFollowUps(t *testing.T) {
+	ask := func(question string) model {
+		m := initialModel().openFAQ()
+		m.faq.input.Set(question)
+		return m.answer()
+	}
+
+	// A confident answer offers its own follow-ups
+	m := ask("Are you available for hire?")
+	if m.faq.answer == nil || m.faq.answer.faq.ID != "hire" {
+		t.Fatalf("no answer for the hire question")
+	}
+	hire := faqBot.faqs[faqBot.byID["hire"]]
+	var want []string
+	for _, id := range hire.Follow {
+		want = append(want, faqBot.faqs[faqBot.byID[id]].Question)
+	}
+	if !reflect.DeepEqual(m.faq.followUp, want) {
+		t.Errorf("follow-ups = %q, want %q", m.faq.followUp, want)
+	}
+	if m.faq.input.String() != "" {
+		t.Errorf("input not cleared after asking")
+	}
+
+	// No answer offers the first few instead
+	m = ask("xyzzy plugh")
+	if m.faq.answer != nil {
+		t.Errorf("answered %q for nonsense", m.faq.answer.faq.ID)
+	}
+	if len(m.faq.followUp) != faqFollowUps {
+		t.Errorf("%d suggestions, want %d", len(m.faq.followUp), faqFollowUps)
+	}
+
+	// Tab steps through the suggestions
+	for i := 0; i < faqFollowUps+1; i++ {
+		tm, _ := m.updateFAQ(tea.KeyMsg{Type: tea.KeyTab})
+		m = tm.(model)
+		if got, want := m.faq.input.String(), m.faq.followUp[i%len(m.faq.followUp)]; got != want {
+			t.Errorf("tab %d filled in %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestNewFAQIndexErrors(t *testing.T) {
+	ok := FAQ{ID: "a", Question: "Q?", Answer: "A."}
+	tests := []struct {
+		name string
+		faqs []FAQ
+		err  string
+	}{
+		{"missing answer", []FAQ{{ID: "a", Question: "Q?"}}, "needs an ID, a question and an answer"},
+		{"duplicate", []FAQ{ok, ok}, `faq "a" listed twice`},
+		{"dangling follow-up", []FAQ{{ID: "a", Question: "Q?", Answer: "A.", Follow: []string{"b"}}}, `follow-up "b" doesn't exist`},
+	}
+	for _, tt := range tests {
+		if _, err := newFAQIndex(tt.faqs); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: newFAQIndex: %v, want %q", tt.name, err, tt.err)
+		}
+	}
+	if _, err := newFAQIndex(faqs); err != nil {
+		t.Errorf("the content's FAQs: %v", err)
+	}
+}
+
+// Long questions wrap to the answer's width, like the answer does.
+func TestRenderFAQWraps(t *testing.T) {
+	m := initialModel().openFAQ()
+	m.faq.input.Set(strings.Repeat("what about this very long question ", 5))
+	m = m.answer()
+	const contentWidth = 50
+	for _, line := range strings.Split(m.renderFAQ(contentWidth), "\n") {
+		if w := lipgloss.Width(line); w > contentWidth {
+			t.Errorf("line is %d wide, more than %d: %q", w, contentWidth, line)
+		}
+	}
+}
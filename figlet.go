@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// --- FIGLET FONTS ---
+
+// figFont is a FIGlet font. Only what the logo needs is supported:
+// characters are laid out at full width, without kerning or smushing,
+// which is how the block fonts in fonts/ are meant to be set anyway.
+type figFont struct {
+	name   string
+	height int
+	glyphs map[rune][]string
+}
+
+// parseFont reads a .flf file. The header is
+//
+//	flf2a<hardblank> height baseline max_length old_layout comment_lines ...
+//
+// followed by the comment lines and then height lines per character for
+// ASCII 32 to 126. Every line ends in an end mark, doubled on the last
+// line of a character. Anything after the required characters is ignored.
+func parseFont(name string, data []byte) (*figFont, error) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	if !sc.Scan() {
+		return nil, fmt.Errorf("font %s: empty file", name)
+	}
+	header := strings.Fields(sc.Text())
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || utf8.RuneCountInString(header[0]) != 6 {
+		return nil, fmt.Errorf("font %s: not a FIGlet font", name)
+	}
+	hardblank, _ := utf8.DecodeLastRuneInString(header[0])
+	height, err := strconv.Atoi(header[1])
+	if err != nil || height < 1 {
+		return nil, fmt.Errorf("font %s: bad height %q", name, header[1])
+	}
+	comments, err := strconv.Atoi(header[5])
+	if err != nil || comments < 0 {
+		return nil, fmt.Errorf("font %s: bad comment count %q", name, header[5])
+	}
+	for i := 0; i < comments; i++ {
+		if !sc.Scan() {
+			return nil, fmt.Errorf("font %s: truncated comments", name)
+		}
+	}
+
+	f := &figFont{name: name, height: height, glyphs: make(map[rune][]string)}
+	for r := rune(32); r <= 126; r++ {
+		rows := make([]string, height)
+		for i := range rows {
+			if !sc.Scan() {
+				return nil, fmt.Errorf("font %s: truncated at character %q", name, r)
+			}
+			line := sc.Text()
+			mark, _ := utf8.DecodeLastRuneInString(line)
+			line = strings.TrimRight(line, string(mark))
+			rows[i] = strings.ReplaceAll(line, string(hardblank), " ")
+		}
+		// Pad every row to the glyph's width so characters line up
+		w := 0
+		for _, row := range rows {
+			if n := utf8.RuneCountInString(row); n > w {
+				w = n
+			}
+		}
+		for i, row := range rows {
+			rows[i] = row + strings.Repeat(" ", w-utf8.RuneCountInString(row))
+		}
+		f.glyphs[r] = rows
+	}
+	return f, sc.Err()
+}
+
+// glyph returns the rows for r, or an empty glyph for characters the font
+// doesn't have.
+func (f *figFont) glyph(r rune) []string {
+	if g, ok := f.glyphs[r]; ok {
+		return g
+	}
+	return make([]string, f.height)
+}
+
+// covers reports whether the font draws every character of text. Fonts
+// leave out what they don't have as blank rows, so only a space may be
+// blank.
+func (f *figFont) covers(text string) bool {
+	for _, r := range text {
+		if r == ' ' {
+			continue
+		}
+		if strings.TrimSpace(strings.Join(f.glyphs[r], "")) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// width is how many columns text takes up when rendered.
+func (f *figFont) width(text string) int {
+	w := 0
+	for _, r := range text {
+		w += utf8.RuneCountInString(f.glyph(r)[0])
+	}
+	return w
+}
+
+// render sets text on a single line of FIGlet characters.
+func (f *figFont) render(text string) []string {
+	rows := make([]string, f.height)
+	for _, r := range text {
+		for i, row := range f.glyph(r) {
+			rows[i] += row
+		}
+	}
+	return rows
+}
+
+//go:embed fonts/*.flf
+var fontFiles embed.FS
+
+// figFonts holds every embedded font, tallest first.
+var figFonts = func() []*figFont {
+	files, err := fontFiles.ReadDir("fonts")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var fonts []*figFont
+	for _, file := range files {
+		data, err := fontFiles.ReadFile(path.Join("fonts", file.Name()))
+		if err != nil {
+			log.Fatalln(err)
+		}
+		f, err := parseFont(strings.TrimSuffix(file.Name(), ".flf"), data)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fonts = append(fonts, f)
+	}
+	sort.Slice(fonts, func(i, j int) bool { return fonts[i].height > fonts[j].height })
+	return fonts
+}()
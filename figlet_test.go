@@ -0,0 +1,137 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// tinyFont writes a two row font where every character is drawn as
+// itself on both rows, except those in glyphs, written as they are.
+func tinyFont(header string, comments int, glyphs map[rune][2]string) string {
+	var b strings.Builder
+	b.WriteString(header + "\n")
+	for i := 0; i < comments; i++ {
+		b.WriteString("a comment\n")
+	}
+	for r := rune(32); r <= 126; r++ {
+		rows, ok := glyphs[r]
+		if !ok {
+			rows = [2]string{string(r) + "@", string(r) + "@@"}
+		}
+		b.WriteString(rows[0] + "\n" + rows[1] + "\n")
+	}
+	return b.String()
+}
+
+func TestParseFont(t *testing.T) {
+	font := tinyFont("flf2a$ 2 1 4 0 1", 1, map[rune][2]string{
+		'A': {" /\\ @", "/--\\@@"},
+		'I': {"|$@", "|@@"},
+		// A different end mark, and a glyph shorter on one row
+		'T': {"---#", "|#"},
+		// Left out, the way fonts do it
+		'#': {"$@", "@@"},
+	})
+	f, err := parseFont("tiny", []byte(font))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		r    rune
+		want []string
+	}{
+		{'A', []string{" /\\ ", "/--\\"}},
+		{'I', []string{"| ", "| "}},
+		{'T', []string{"---", "|  "}},
+		{'z', []string{"z", "z"}},
+		{'é', []string{"", ""}},
+	}
+	for _, tt := range tests {
+		if got := f.glyph(tt.r); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("glyph(%q) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+	if got, want := f.render("AIT"), []string{" /\\ | ---", "/--\\| |  "}; !reflect.DeepEqual(got, want) {
+		t.Errorf("render = %q, want %q", got, want)
+	}
+	if got := f.width("AIT"); got != 9 {
+		t.Errorf("width = %d, want 9", got)
+	}
+	for text, want := range map[string]bool{"AIT": true, "A I T": true, "": true, "A#": false, "é": false} {
+		if got := f.covers(text); got != want {
+			t.Errorf("covers(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestParseFontErrors(t *testing.T) {
+	full := tinyFont("flf2a$ 2 1 4 0 1", 1, nil)
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"empty", "", "empty file"},
+		{"not figlet", "hello world 1 2 3 4\n", "not a FIGlet font"},
+		{"short header", "flf2a$ 2 1\n", "not a FIGlet font"},
+		{"long signature", "flf2a$$ 2 1 4 0 0\n", "not a FIGlet font"},
+		{"bad height", "flf2a$ x 1 4 0 0\n", `bad height "x"`},
+		{"zero height", "flf2a$ 0 1 4 0 0\n", `bad height "0"`},
+		{"bad comments", "flf2a$ 2 1 4 0 -1\n", `bad comment count "-1"`},
+		{"truncated comments", "flf2a$ 2 1 4 0 3\none\n", "truncated comments"},
+		{"truncated glyphs", full[:len(full)/2], "truncated at character"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFont("bad", []byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseFont: %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+// The embedded fonts have every printable ASCII character, each a
+// rectangle.
+func TestEmbeddedFonts(t *testing.T) {
+	if len(figFonts) == 0 {
+		t.Fatal("no fonts embedded")
+	}
+	for _, f := range figFonts {
+		for r := rune(32); r <= 126; r++ {
+			g, ok := f.glyphs[r]
+			if !ok || len(g) != f.height {
+				t.Fatalf("%s: %q has %d rows, want %d", f.name, r, len(g), f.height)
+			}
+			for _, row := range g {
+				if utf8.RuneCountInString(row) != utf8.RuneCountInString(g[0]) {
+					t.Errorf("%s: %q has rows of different widths", f.name, r)
+					break
+				}
+			}
+		}
+	}
+}
+
+// term is plain text: every character, '@' and '$' included, is itself.
+func TestTermFont(t *testing.T) {
+	var term *figFont
+	for _, f := range figFonts {
+		if f.name == "term" {
+			term = f
+		}
+	}
+	if term == nil {
+		t.Fatal("no term font")
+	}
+	for r := rune(32); r <= 126; r++ {
+		if got := term.glyph(r); !reflect.DeepEqual(got, []string{string(r)}) {
+			t.Errorf("glyph(%q) = %q", r, got)
+		}
+	}
+	if got := term.render("me@x.io $5"); !reflect.DeepEqual(got, []string{"me@x.io $5"}) {
+		t.Errorf("render = %q", got)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// --- LOGO & BORDER EFFECTS ---
+
+// Frames are tickCmd ticks, 50ms each
+const (
+	bootFrames    = 24 // the logo wipes in once, after the splash
+	shimmerFrames = 30 // one pass of the highlight across the logo
+	shimmerEvery  = 8 * time.Second
+	shimmerBand   = 0.12 // half width of the highlight, as a share of the logo
+)
+
+var (
+	shimmerGlow = lipgloss.Color("#FFE3D3")
+	borderGlow  = lipgloss.Color("#FFB38A")
+)
+
+// logoFX is where the logo animations are up to. The zero value draws
+// the logo and border still.
+type logoFX struct {
+	boot    int // frames of the boot reveal played, 0 when not booting
+	shimmer int // frames into the current shimmer pass, 0 when idle
+}
+
+func (fx logoFX) active() bool {
+	return fx.boot > 0 || fx.shimmer > 0
+}
+
+// step moves the animation on a frame and reports if there's more to play.
+func (fx *logoFX) step() bool {
+	if fx.boot > 0 {
+		fx.boot++
+		if fx.boot > bootFrames {
+			fx.boot = 0
+		}
+	} else if fx.shimmer > 0 {
+		fx.shimmer++
+		if fx.shimmer > shimmerFrames {
+			fx.shimmer = 0
+		}
+	}
+	return fx.active()
+}
+
+// revealed reports whether cell x, y of a w by h logo is showing yet. The
+// boot reveal wipes in diagonally from the top left.
+func (fx logoFX) revealed(x, y, w, h int) bool {
+	if fx.boot == 0 {
+		return true
+	}
+	progress := float64(fx.boot) / bootFrames
+	return float64(x+2*y) <= progress*float64(w+2*h)
+}
+
+// glow is how much of the shimmer highlight lands on column x of w, from
+// 0 to 1.
+func (fx logoFX) glow(x, w int) float64 {
+	if fx.shimmer == 0 || w == 0 {
+		return 0
+	}
+	// The band starts and ends just off the logo
+	center := -shimmerBand + float64(fx.shimmer)/shimmerFrames*(1+2*shimmerBand)
+	d := math.Abs(float64(x)/float64(w) - center)
+	if d >= shimmerBand {
+		return 0
+	}
+	return 1 - d/shimmerBand
+}
+
+// borderColor pulses the border with the shimmer and fades it in during
+// the boot reveal.
+func (fx logoFX) borderColor() lipgloss.Color {
+	switch {
+	case fx.boot > 0:
+		return blendColor(dimmed, accent, float64(fx.boot)/bootFrames)
+	case fx.shimmer > 0:
+		return blendColor(accent, borderGlow, math.Sin(math.Pi*float64(fx.shimmer)/shimmerFrames))
+	}
+	return accent
+}
+
+// animated reports whether the logo and border may move: the visitor
+// hasn't turned motion off and their terminal can show the gradients.
+func (m model) animated() bool {
+	return m.motion && m.colors <= termenv.ANSI256
+}
+
+// Terminals too slow or plain to redraw the logo every frame
+var stillTerms = map[string]bool{"linux": true, "dumb": true, "vt100": true, "vt220": true}
+
+// wantsStill reports whether the visitor asked for reduced motion before
+// connecting, with PORTFOLIO_MOTION=reduce (or off) or NO_MOTION set over
+// SetEnv, or is on a terminal that shouldn't animate. v still turns
+// motion back on.
+func (t terminal) wantsStill() bool {
+	switch strings.ToLower(t.env["PORTFOLIO_MOTION"]) {
+	case "reduce", "reduced", "off", "none", "0":
+		return true
+	}
+	if v, ok := t.env["NO_MOTION"]; ok && v != "0" {
+		return true
+	}
+	return stillTerms[t.term]
+}
+
+// logoVisible is true when the boxed views, and so the logo, are on screen.
+func (m model) logoVisible() bool {
+	return m.saver == nil && !m.farewell &&
+		m.page() != pathSplash && m.page() != pathEffects && m.page() != pathQR
+}
+
+// bootReveal plays the reveal as the home screen first appears.
+func (m *model) bootReveal() tea.Cmd {
+	if !m.animated() {
+		return nil
+	}
+	m.fx.boot = 1
+	return m.tick()
+}
+
+// shimmerDue starts a shimmer pass every shimmerEvery while the logo is up.
+func (m *model) shimmerDue(now time.Time) tea.Cmd {
+	if !m.animated() || !m.logoVisible() || m.fx.active() {
+		return nil
+	}
+	if int(now.Sub(m.sessionStart).Seconds())%int(shimmerEvery.Seconds()) != 0 {
+		return nil
+	}
+	m.fx.shimmer = 1
+	return m.tick()
+}
+
+// toggleMotion turns the logo and border effects on or off.
+func (m model) toggleMotion() (model, tea.Cmd) {
+	m.motion = !m.motion
+	m.fx = logoFX{}
+	if m.motion {
+		return m.showToast("✨ Motion on")
+	}
+	return m.showToast("Motion off")
+}
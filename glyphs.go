@@ -0,0 +1,161 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rivo/uniseg"
+)
+
+// --- GLYPH SETS ---
+
+// glyphSet is how icons and decoration are drawn for one visitor. The
+// content is written with plain Unicode icons; a set can swap those for
+// its own, and the ASCII set rewrites everything else on the way out.
+type glyphSet struct {
+	name           string
+	icons          map[string]string // keyed by the icon in the content
+	confettiEmoji  []string
+	confettiShapes []string
+	confettiFade   string
+	ascii          bool // transliterate every frame to plain ASCII
+}
+
+var (
+	unicodeGlyphs = glyphSet{
+		name:           "unicode",
+		confettiEmoji:  []string{"🎉", "✨", "🎊", "⭐", "💫", "🌟"},
+		confettiShapes: []string{"●", "■", "▲", "◆", "✦", "*"},
+		confettiFade:   "·",
+	}
+	// Font Awesome codepoints, which every Nerd Font patches in
+	nerdGlyphs = glyphSet{
+		name: "nerd",
+		icons: map[string]string{
+			"◈": "\uf207", // bus
+			"⬡": "\uf0ec", // exchange
+			"◉": "\uf086", // comments
+			"●": "\uf007", // user
+			"◐": "\uf09b", // github
+			"◧": "\uf0e1", // linkedin
+			"✉": "\uf0e0", // envelope
+		},
+		confettiEmoji:  unicodeGlyphs.confettiEmoji,
+		confettiShapes: []string{"\uf005", "\uf004", "\uf0e7", "\uf135", "\uf06d", "*"}, // star, heart, bolt, rocket, fire
+		confettiFade:   "·",
+	}
+	asciiGlyphs = glyphSet{
+		name: "ascii",
+		icons: map[string]string{
+			"◈": "+", "⬡": "#", "◉": "@", "●": "*",
+			"◐": ">", "◧": ">", "✉": "@",
+		},
+		confettiEmoji:  []string{"$", "&"},
+		confettiShapes: []string{"o", "#", "^", "+", "x", "*"},
+		confettiFade:   ".",
+		ascii:          true,
+	}
+	glyphSets = []glyphSet{unicodeGlyphs, nerdGlyphs, asciiGlyphs}
+)
+
+// icon is the set's version of a content icon. Anything after the icon,
+// like the space socials carry, is kept.
+func (g glyphSet) icon(s string) string {
+	trimmed := strings.TrimRight(s, " ")
+	if alt, ok := g.icons[trimmed]; ok {
+		return alt + s[len(trimmed):]
+	}
+	return s
+}
+
+// asciiText stands in for the Unicode the views draw with. Every entry is
+// one column wide like the glyph it replaces, so boxes and centering come
+// out the same.
+var asciiText = map[rune]string{
+	'─': "-", '━': "=", '═': "=", '│': "|", '┃': "|", '║': "|",
+	'┌': "+", '┐': "+", '└': "+", '┘': "+", '├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+	'╔': "+", '╗': "+", '╚': "+", '╝': "+", '╠': "+", '╣': "+", '╦': "+", '╩': "+", '╬': "+",
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+",
+	'█': "#", '▓': "#", '▒': ":", '░': ".", '▀': "\"", '▄': "_",
+	'·': "-", '…': ".", '›': ">", '▸': ">", '→': ">", '←': "<", '↑': "^", '↓': "v",
+	'◆': "*", '◇': "o", '●': "*", '■': "#", '▲': "^", '✦': "*", '★': "*", '✎': "/",
+	'×': "x", '©': "c", '²': "2",
+}
+
+// apply rewrites a finished frame for the set. Only the ASCII set changes
+// anything: known glyphs get their stand in, and any other wide or unusual
+// character a plain "*" padded to the width it had, one grapheme cluster
+// at a time so emoji sequences are replaced whole.
+func (g glyphSet) apply(frame string) string {
+	if !g.ascii {
+		return frame
+	}
+	var b strings.Builder
+	b.Grow(len(frame))
+	state := -1
+	for frame != "" {
+		var cluster string
+		var width int
+		cluster, frame, width, state = uniseg.FirstGraphemeClusterInString(frame, state)
+		if isASCII(cluster) {
+			b.WriteString(cluster)
+			continue
+		}
+		r := []rune(cluster)[0]
+		switch alt, ok := asciiText[r]; {
+		case ok:
+			b.WriteString(alt)
+		case r >= 0xFF61 && r <= 0xFF9F:
+			// Half width katakana, the matrix rain
+			b.WriteRune('a' + (r-0xFF61)%26)
+		case width > 0:
+			b.WriteString("*" + strings.Repeat(" ", width-1))
+		}
+	}
+	return b.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// glyphsFor picks a set from what the client told us. PORTFOLIO_GLYPHS
+// (unicode, nerd or ascii, e.g. ssh -o SetEnv=PORTFOLIO_GLYPHS=nerd) wins;
+// otherwise consoles and non UTF-8 locales get ASCII.
+func glyphsFor(t terminal) glyphSet {
+	for _, g := range glyphSets {
+		if strings.EqualFold(t.env["PORTFOLIO_GLYPHS"], g.name) {
+			return g
+		}
+	}
+	switch t.term {
+	case "linux", "dumb", "vt100", "vt220", "cygwin":
+		return asciiGlyphs
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := t.env[key]; locale != "" {
+			locale = strings.ToLower(locale)
+			if !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8") {
+				return asciiGlyphs
+			}
+			break
+		}
+	}
+	return unicodeGlyphs
+}
+
+// cycleGlyphs moves on to the next set.
+func (m model) cycleGlyphs() (model, tea.Cmd) {
+	for i, g := range glyphSets {
+		if g.name == m.glyphs.name {
+			m.glyphs = glyphSets[(i+1)%len(glyphSets)]
+			break
+		}
+	}
+	return m.showToast("Glyphs: " + m.glyphs.name)
+}
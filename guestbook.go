@@ -0,0 +1,147 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- GUESTBOOK ---
+
+const (
+	guestbookMessageLimit = 120
+	guestbookShown        = 6
+)
+
+// Keys allowed to hide guestbook entries, by SHA256 fingerprint
+var guestbookModerators = map[string]bool{}
+
+// loadGuestbookConfig reads PORTFOLIO_MODERATORS, fingerprints separated
+// by commas or spaces.
+func loadGuestbookConfig() {
+	for _, fp := range strings.FieldsFunc(os.Getenv("PORTFOLIO_MODERATORS"), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		if !strings.HasPrefix(fp, "SHA256:") {
+			log.Fatalf("PORTFOLIO_MODERATORS: %q is not a SHA256 fingerprint", fp)
+		}
+		guestbookModerators[fp] = true
+	}
+}
+
+func (m model) moderator() bool {
+	return m.fingerprint != "" && guestbookModerators[m.fingerprint]
+}
+
+func (m model) openGuestbook() model {
+	m = m.navigate(pathGuestbook)
+	m.guestInput = newLineInput(guestbookMessageLimit)
+	m.guestEntries = m.store.Guestbook()
+	m.guestPick = len(m.shownEntries()) - 1
+	return m
+}
+
+// shownEntries is the newest few, the ones on screen.
+func (m model) shownEntries() []guestbookEntry {
+	entries := m.guestEntries
+	if len(entries) > guestbookShown {
+		entries = entries[len(entries)-guestbookShown:]
+	}
+	return entries
+}
+
+func (m model) updateGuestbook(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m = m.back()
+		return m, nil
+	case tea.KeyEnter:
+		text := strings.TrimSpace(m.guestInput.String())
+		if text == "" {
+			return m, nil
+		}
+		entry := guestbookEntry{Name: m.user, Key: m.fingerprint, Message: text, When: time.Now()}
+		entry, err := m.store.Sign(entry)
+		if err != nil {
+			return m.showToast("✎ " + err.Error())
+		}
+		m.guestInput.Reset()
+		m.guestEntries = append(m.guestEntries, entry)
+		m.guestPick = len(m.shownEntries()) - 1
+		return m.unlock("guestbook")
+	}
+	if m.moderator() {
+		shown := m.shownEntries()
+		switch msg.Type {
+		case tea.KeyUp:
+			m.guestPick = max(m.guestPick-1, 0)
+			return m, nil
+		case tea.KeyDown:
+			m.guestPick = min(m.guestPick+1, len(shown)-1)
+			return m, nil
+		case tea.KeyCtrlX:
+			if m.guestPick >= 0 && m.guestPick < len(shown) && m.store.Hide(shown[m.guestPick].ID) {
+				m.guestEntries = m.store.Guestbook()
+				m.guestPick = min(m.guestPick, len(m.shownEntries())-1)
+			}
+			return m, nil
+		}
+	}
+	m.guestInput.Update(msg)
+	return m, nil
+}
+
+func (m model) renderGuestbook(contentWidth int) string {
+	var b strings.Builder
+
+	section := sectionStyle.Render("▸ GUESTBOOK")
+	b.WriteString(centerText(section, contentWidth))
+	b.WriteString("\n\n")
+
+	entries := m.shownEntries()
+	if len(entries) == 0 {
+		b.WriteString(centerText(hintStyle.Render("Nobody has signed yet. Be the first!"), contentWidth))
+		b.WriteString("\n")
+	}
+	msgWidth := contentWidth - 8
+	if msgWidth > 56 {
+		msgWidth = 56
+	}
+	var lines []string
+	moderating := m.moderator()
+	for i, e := range entries {
+		name := e.Name
+		if name == "" {
+			name = "anonymous"
+		}
+		header := socialIcon.Render(name) + " " + hintStyle.Render(e.When.Format("Jan 2"))
+		if moderating && i == m.guestPick {
+			header = socialIcon.Render("▸ ") + header
+		}
+		body := lipgloss.NewStyle().Foreground(fgDim).Width(msgWidth).Render(e.Message)
+		lines = append(lines, header, body, "")
+	}
+	if len(lines) > 0 {
+		b.WriteString(centerBlock(lipgloss.JoinVertical(lipgloss.Left, lines...), contentWidth))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	prompt := socialIcon.Render("✎ ") + m.guestInput.View(lipgloss.NewStyle().Foreground(fg), msgWidth-2)
+	b.WriteString(centerBlock(lipgloss.NewStyle().Width(msgWidth).Render(prompt), contentWidth))
+	b.WriteString("\n\n")
+
+	hints := "type a message · enter sign · esc back"
+	if moderating {
+		hints = "↑/↓ pick · ctrl+x hide · " + hints
+	}
+	hints = hintStyle.Render(hints)
+	b.WriteString(centerText(hints, contentWidth))
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGuestbookModeration(t *testing.T) {
+	st, err := openStore(filepath.Join(t.TempDir(), "visitors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Flush()
+	for i, msg := range []string{"first", "spam", "last"} {
+		key := "SHA256:" + msg
+		if _, err := st.Sign(guestbookEntry{Key: key, Message: msg, When: time.Now().Add(time.Duration(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	guestbookModerators["SHA256:owner"] = true
+	defer delete(guestbookModerators, "SHA256:owner")
+
+	press := func(m model, k tea.KeyType) model {
+		tm, _ := m.updateGuestbook(tea.KeyMsg{Type: k})
+		return tm.(model)
+	}
+	visitor := initialModel()
+	visitor.store, visitor.fingerprint = st, "SHA256:visitor"
+	visitor = press(visitor.openGuestbook(), tea.KeyUp)
+	visitor = press(visitor, tea.KeyCtrlX)
+	if len(st.Guestbook()) != 3 {
+		t.Errorf("a visitor hid an entry")
+	}
+	if strings.Contains(visitor.renderGuestbook(60), "ctrl+x hide") {
+		t.Errorf("visitors are told how to hide entries")
+	}
+
+	owner := initialModel()
+	owner.store, owner.fingerprint = st, "SHA256:owner"
+	owner = press(owner.openGuestbook(), tea.KeyUp)
+	if !strings.Contains(owner.renderGuestbook(60), "ctrl+x hide") {
+		t.Errorf("moderators aren't told how to hide entries")
+	}
+	owner = press(owner, tea.KeyCtrlX)
+	var left []string
+	for _, e := range st.Guestbook() {
+		left = append(left, e.Message)
+	}
+	if strings.Join(left, " ") != "first last" {
+		t.Errorf("guestbook after hiding = %q, want first and last", left)
+	}
+	if len(owner.guestEntries) != 2 {
+		t.Errorf("the moderator still sees %d entries", len(owner.guestEntries))
+	}
+}
+
+func TestGuestbookSignTooSoon(t *testing.T) {
+	st, err := openStore(filepath.Join(t.TempDir(), "visitors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Flush()
+	m := initialModel()
+	m.store, m.fingerprint = st, "SHA256:visitor"
+	m = m.openGuestbook()
+	for _, msg := range []string{"hello", "hello again"} {
+		m.guestInput.Set(msg)
+		tm, _ := m.updateGuestbook(tea.KeyMsg{Type: tea.KeyEnter})
+		m = tm.(model)
+	}
+	if n := len(st.Guestbook()); n != 1 {
+		t.Errorf("%d entries, want 1", n)
+	}
+	if !strings.Contains(m.toast, errSignTooSoon.Error()) {
+		t.Errorf("toast = %q, want the cooldown", m.toast)
+	}
+	if m.guestInput.String() != "hello again" {
+		t.Errorf("the refused message was thrown away")
+	}
+}
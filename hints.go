@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// --- HINT ENGINE ---
+
+// How long each footer hint stays up
+const hintRotation = 8 * time.Second
+
+// After each of these a visitor gets the next, more specific, hint level
+var hintLevels = []time.Duration{0, 90 * time.Second, 4 * time.Minute}
+
+const allFoundHint = "You found every secret. Impressive."
+
+// hintFor picks the footer hint for a session. It only depends on its
+// arguments, so the same seed, time and discoveries give the same hint.
+// Hints come from eggs the visitor hasn't found yet, in a per-session
+// order, and get more specific the longer the session lasts.
+func hintFor(seed int64, elapsed time.Duration, found map[string]bool) string {
+	// Eggs are shuffled once per session; the slot moves on with time
+	var pending []EasterEgg
+	for _, i := range rand.New(rand.NewSource(seed)).Perm(len(easterEggRegistry.eggs)) {
+		e := easterEggRegistry.eggs[i]
+		if !found[e.ID()] && len(e.Hints()) > 0 {
+			pending = append(pending, e)
+		}
+	}
+	if len(pending) == 0 {
+		return allFoundHint
+	}
+	egg := pending[int(elapsed/hintRotation)%len(pending)]
+
+	level := 0
+	for i, after := range hintLevels {
+		if elapsed >= after {
+			level = i
+		}
+	}
+	hints := egg.Hints()
+	if level >= len(hints) {
+		level = len(hints) - 1
+	}
+	return hints[level]
+}
+
+func (m model) hint() string {
+	return hintFor(m.hintSeed, time.Since(m.sessionStart), m.discovered)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// hintOwners maps each hint to the eggs it gives away.
+func hintOwners() map[string][]string {
+	owners := map[string][]string{}
+	for _, e := range easterEggRegistry.eggs {
+		for _, h := range e.Hints() {
+			owners[h] = append(owners[h], e.ID())
+		}
+	}
+	return owners
+}
+
+func allFound() map[string]bool {
+	found := map[string]bool{}
+	for _, e := range easterEggRegistry.eggs {
+		found[e.ID()] = true
+	}
+	return found
+}
+
+func TestHintForIsDeterministic(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    int64
+		elapsed time.Duration
+		found   map[string]bool
+	}{
+		{"fresh session", 1, 0, map[string]bool{}},
+		{"later level", 42, 5 * time.Minute, map[string]bool{}},
+		{"some found", 7, 30 * time.Second, map[string]bool{"konami": true}},
+		{"negative seed", -3, 2 * time.Minute, map[string]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := hintFor(tt.seed, tt.elapsed, tt.found)
+			for i := 0; i < 5; i++ {
+				if got := hintFor(tt.seed, tt.elapsed, tt.found); got != first {
+					t.Fatalf("hintFor gave %q, then %q", first, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHintForSkipsFoundEggs(t *testing.T) {
+	owners := hintOwners()
+	for _, e := range easterEggRegistry.eggs {
+		// Everything but e found: only e may be hinted
+		found := allFound()
+		delete(found, e.ID())
+		for seed := int64(0); seed < 10; seed++ {
+			for _, elapsed := range []time.Duration{0, hintRotation, 2 * time.Minute, 10 * time.Minute} {
+				got := hintFor(seed, elapsed, found)
+				if len(e.Hints()) == 0 {
+					if got != allFoundHint {
+						t.Errorf("%s has no hints, but hintFor gave %q", e.ID(), got)
+					}
+					continue
+				}
+				ok := false
+				for _, id := range owners[got] {
+					ok = ok || id == e.ID()
+				}
+				if !ok {
+					t.Errorf("only %s is left, but hintFor(%d, %v) gave %q", e.ID(), seed, elapsed, got)
+				}
+			}
+		}
+	}
+}
+
+func TestHintForAllFound(t *testing.T) {
+	if got := hintFor(1, time.Minute, allFound()); got != allFoundHint {
+		t.Errorf("hintFor with every egg found = %q, want %q", got, allFoundHint)
+	}
+}
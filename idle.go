@@ -0,0 +1,101 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- IDLE HANDLING ---
+
+// Defaults, overridable from the environment (see loadIdleConfig). A
+// zero duration turns the behaviour off.
+var (
+	screensaverAfter  = 2 * time.Minute
+	screensaverEffect = "matrix"
+	idleTimeout       = 15 * time.Minute
+)
+
+// How long the farewell stays up before the connection is closed
+const farewellDuration = 3 * time.Second
+
+type farewellDoneMsg struct{}
+
+// loadIdleConfig reads PORTFOLIO_SCREENSAVER_AFTER, PORTFOLIO_SCREENSAVER
+// and PORTFOLIO_IDLE_TIMEOUT.
+func loadIdleConfig() {
+	durationEnv("PORTFOLIO_SCREENSAVER_AFTER", &screensaverAfter)
+	durationEnv("PORTFOLIO_IDLE_TIMEOUT", &idleTimeout)
+	if name := os.Getenv("PORTFOLIO_SCREENSAVER"); name != "" {
+		known := false
+		for _, e := range newEffects() {
+			known = known || e.Name() == name
+		}
+		if !known {
+			log.Fatalf("PORTFOLIO_SCREENSAVER: unknown effect %q", name)
+		}
+		screensaverEffect = name
+	}
+}
+
+func durationEnv(key string, d *time.Duration) {
+	v := os.Getenv(key)
+	if v == "" {
+		return
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("%s: %v", key, err)
+	}
+	*d = parsed
+}
+
+// checkIdle runs on every clock tick. The screensaver sits on top of the
+// current view without touching it, so the next key resumes exactly
+// where the visitor left off.
+func (m model) checkIdle(idle time.Duration) (model, tea.Cmd) {
+	if m.farewell {
+		return m, nil
+	}
+	if idleTimeout > 0 && idle >= idleTimeout {
+		m.farewell = true
+		m.saver = nil
+		return m, tea.Tick(farewellDuration, func(time.Time) tea.Msg {
+			return farewellDoneMsg{}
+		})
+	}
+	// The splash and the gallery are animated already
+	if screensaverAfter > 0 && idle >= screensaverAfter && m.saver == nil &&
+		m.page() != pathSplash && m.page() != pathEffects {
+		for _, e := range newEffects() {
+			if e.Name() == screensaverEffect {
+				m.saver = e
+			}
+		}
+		m.saver.Init(m.width, m.height)
+		return m, m.tick()
+	}
+	return m, nil
+}
+
+var farewellStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(accent).
+	Padding(1, 3).
+	Align(lipgloss.Center)
+
+func (m model) renderFarewell() string {
+	msg := strings.Join([]string{
+		titleStyle.Render("Still there?"),
+		"",
+		socialText.Render("This session has been idle for a while,"),
+		socialText.Render("so it's closing to free up the server."),
+		"",
+		quoteStyle.Render("Thanks for stopping by. ssh back anytime!"),
+	}, "\n")
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, farewellStyle.Render(msg))
+}
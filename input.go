@@ -0,0 +1,144 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- LINE INPUT ---
+
+// lineInput is a minimal single-line text field for the views that take
+// free text. It only ever holds printable runes, so whatever visitors
+// type can't smuggle escape sequences into other people's screens.
+type lineInput struct {
+	value  []rune
+	cursor int
+	limit  int
+}
+
+func newLineInput(limit int) lineInput {
+	return lineInput{limit: limit}
+}
+
+// printable drops control characters from text that came from a client
+func printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func (in lineInput) String() string {
+	return string(in.value)
+}
+
+func (in *lineInput) Set(s string) {
+	in.value = []rune(s)
+	in.cursor = len(in.value)
+}
+
+// Before is the text left of the cursor.
+func (in lineInput) Before() string {
+	return string(in.value[:in.cursor])
+}
+
+// SetBefore replaces the text left of the cursor with s, keeping what's
+// after it, and leaves the cursor at the end of s.
+func (in *lineInput) SetBefore(s string) {
+	after := in.value[in.cursor:]
+	in.value = append([]rune(s), after...)
+	in.cursor = len(in.value) - len(after)
+}
+
+func (in *lineInput) Reset() {
+	in.value = nil
+	in.cursor = 0
+}
+
+func (in *lineInput) insert(runes []rune) {
+	for _, r := range runes {
+		if !unicode.IsPrint(r) {
+			continue
+		}
+		if in.limit > 0 && len(in.value) >= in.limit {
+			return
+		}
+		in.value = append(in.value[:in.cursor], append([]rune{r}, in.value[in.cursor:]...)...)
+		in.cursor++
+	}
+}
+
+// Update applies an editing key and reports whether it was one.
+func (in *lineInput) Update(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		in.insert(msg.Runes)
+	case tea.KeySpace:
+		in.insert([]rune{' '})
+	case tea.KeyBackspace:
+		if in.cursor > 0 {
+			in.value = append(in.value[:in.cursor-1], in.value[in.cursor:]...)
+			in.cursor--
+		}
+	case tea.KeyDelete:
+		if in.cursor < len(in.value) {
+			in.value = append(in.value[:in.cursor], in.value[in.cursor+1:]...)
+		}
+	case tea.KeyLeft:
+		if in.cursor > 0 {
+			in.cursor--
+		}
+	case tea.KeyRight:
+		if in.cursor < len(in.value) {
+			in.cursor++
+		}
+	case tea.KeyHome, tea.KeyCtrlA:
+		in.cursor = 0
+	case tea.KeyEnd, tea.KeyCtrlE:
+		in.cursor = len(in.value)
+	case tea.KeyCtrlU:
+		in.value = in.value[in.cursor:]
+		in.cursor = 0
+	case tea.KeyCtrlW:
+		// Delete the word before the cursor
+		i := in.cursor
+		for i > 0 && in.value[i-1] == ' ' {
+			i--
+		}
+		for i > 0 && in.value[i-1] != ' ' {
+			i--
+		}
+		in.value = append(in.value[:i], in.value[in.cursor:]...)
+		in.cursor = i
+	default:
+		return false
+	}
+	return true
+}
+
+var inputCursor = lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(accent)
+
+// View renders the value with a block cursor, scrolling so the cursor
+// stays visible when the text is wider than width.
+func (in lineInput) View(style lipgloss.Style, width int) string {
+	before, after := in.value[:in.cursor], []rune(nil)
+	under := ' '
+	if in.cursor < len(in.value) {
+		under = in.value[in.cursor]
+		after = in.value[in.cursor+1:]
+	}
+	if width > 0 {
+		for len(before) > 0 && lipgloss.Width(string(before))+1 > width {
+			before = before[1:]
+		}
+		for len(after) > 0 && lipgloss.Width(string(before))+1+lipgloss.Width(string(after)) > width {
+			after = after[:len(after)-1]
+		}
+	}
+	return style.Render(string(before)) + inputCursor.Render(string(under)) + style.Render(string(after))
+}
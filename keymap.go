@@ -0,0 +1,363 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- KEYMAP ---
+
+// action is something a key can be bound to. Update switches on actions,
+// never on keys, and the help page is written from the same bindings, so
+// the two can't drift apart.
+type action string
+
+const (
+	actUp           action = "up"
+	actDown         action = "down"
+	actNext         action = "next"
+	actOpen         action = "open"
+	actBack         action = "back"
+	actLeft         action = "left"
+	actRight        action = "right"
+	actPrev         action = "prev"
+	actForward      action = "forward"
+	actQuit         action = "quit"
+	actHelp         action = "help"
+	actTyping       action = "typing"
+	actAchievements action = "achievements"
+	actGuestbook    action = "guestbook"
+	actMotion       action = "motion"
+	actLinks        action = "links"
+	actGlyphs       action = "glyphs"
+	actKeymap       action = "keymap"
+	actCopyLink     action = "copy-link"
+	actQR           action = "qr"
+	actCopySocial   action = "copy-social"
+	actPickMatch    action = "pick-match"
+	actPalette      action = "palette"
+	actShell        action = "shell"
+	actAsk          action = "ask"
+)
+
+// binding ties keys, as tea.KeyMsg.String() spells them, to an action.
+// The first key is the one hints show. A binding with a page, a route as
+// model.page reports it, only works there and wins over the others on it.
+type binding struct {
+	action action
+	keys   []string
+	desc   string
+	label  string // shown in help instead of the keys, if set
+	page   string
+}
+
+// keymap is one preset. ctrl+c always quits and alt+←/→ always move
+// through the history; neither is part of it.
+type keymap struct {
+	name     string
+	bindings []binding
+	byKey    map[string]action
+	onPage   map[string]map[string]action
+}
+
+// Moving around differs between presets; everything else is shared.
+var (
+	defaultNav = []binding{
+		{action: actUp, keys: []string{"up", "k"}, desc: "Navigate up"},
+		{action: actDown, keys: []string{"down", "j"}, desc: "Navigate down"},
+		{action: actNext, keys: []string{"tab"}, desc: "Next item"},
+		{action: actOpen, keys: []string{"enter", " "}, desc: "Open details"},
+		{action: actBack, keys: []string{"esc", "backspace"}, desc: "Go back"},
+		{action: actLeft, keys: []string{"left", "h"}, desc: "Previous effect or QR code"},
+		{action: actRight, keys: []string{"right", "l"}, desc: "Next effect or QR code"},
+		{action: actPrev, keys: []string{"["}, desc: "Back in history (alt+← too)"},
+		{action: actForward, keys: []string{"]"}, desc: "Forward in history (alt+→ too)"},
+		{action: actQuit, keys: []string{"q"}, desc: "Quit, or home from a page"},
+		{action: actHelp, keys: []string{"?"}, desc: "Toggle help"},
+	}
+	vimNav = []binding{
+		{action: actUp, keys: []string{"k"}, desc: "Navigate up"},
+		{action: actDown, keys: []string{"j"}, desc: "Navigate down"},
+		{action: actNext, keys: []string{"tab"}, desc: "Next item"},
+		{action: actOpen, keys: []string{"enter", "o"}, desc: "Open details"},
+		{action: actBack, keys: []string{"esc", "-"}, desc: "Go back"},
+		{action: actLeft, keys: []string{"h", "left"}, desc: "Previous effect or QR code"},
+		{action: actRight, keys: []string{"l", "right"}, desc: "Next effect or QR code"},
+		{action: actPrev, keys: []string{"ctrl+o", "["}, desc: "Back in history (alt+← too)"},
+		{action: actForward, keys: []string{"]"}, desc: "Forward in history (alt+→ too)"},
+		{action: actQuit, keys: []string{"q"}, desc: "Quit, or home from a page"},
+		{action: actHelp, keys: []string{"?"}, desc: "Toggle help"},
+	}
+	emacsNav = []binding{
+		{action: actUp, keys: []string{"ctrl+p", "up"}, desc: "Navigate up"},
+		{action: actDown, keys: []string{"ctrl+n", "down"}, desc: "Navigate down"},
+		{action: actNext, keys: []string{"tab"}, desc: "Next item"},
+		{action: actOpen, keys: []string{"enter"}, desc: "Open details"},
+		{action: actBack, keys: []string{"ctrl+g", "esc"}, desc: "Go back"},
+		{action: actLeft, keys: []string{"ctrl+b", "left"}, desc: "Previous effect or QR code"},
+		{action: actRight, keys: []string{"ctrl+f", "right"}, desc: "Next effect or QR code"},
+		{action: actPrev, keys: []string{"["}, desc: "Back in history (alt+← too)"},
+		{action: actForward, keys: []string{"]"}, desc: "Forward in history (alt+→ too)"},
+		{action: actQuit, keys: []string{"q"}, desc: "Quit, or home from a page"},
+		{action: actHelp, keys: []string{"?"}, desc: "Toggle help"},
+	}
+	arrowsNav = []binding{
+		{action: actUp, keys: []string{"up"}, desc: "Navigate up"},
+		{action: actDown, keys: []string{"down"}, desc: "Navigate down"},
+		{action: actOpen, keys: []string{"enter"}, desc: "Open details"},
+		{action: actBack, keys: []string{"esc", "backspace"}, desc: "Go back"},
+		{action: actLeft, keys: []string{"left"}, desc: "Previous effect or QR code"},
+		{action: actRight, keys: []string{"right"}, desc: "Next effect or QR code"},
+		{action: actQuit, keys: []string{"q"}, desc: "Quit, or home from a page"},
+		{action: actHelp, keys: []string{"?"}, desc: "Toggle help"},
+	}
+	sharedBindings = []binding{
+		{action: actPalette, keys: []string{":", "ctrl+k"}, desc: "Command palette"},
+		{action: actShell, keys: []string{"$"}, desc: "A shell over the portfolio"},
+		{action: actAsk, keys: []string{"A"}, desc: "Ask me anything"},
+		{action: actTyping, keys: []string{"t"}, desc: "Typing speed test"},
+		{action: actAchievements, keys: []string{"a"}, desc: "Achievements"},
+		{action: actGuestbook, keys: []string{"g"}, desc: "Guestbook"},
+		{action: actMotion, keys: []string{"v"}, desc: "Toggle motion effects"},
+		{action: actLinks, keys: []string{"u"}, desc: "Clickable links or footnotes"},
+		{action: actGlyphs, keys: []string{"f"}, desc: "Glyphs: unicode, nerd or ascii"},
+		{action: actKeymap, keys: []string{"K"}, desc: "Keys: default, vim, emacs or arrows"},
+		{action: actCopyLink, keys: []string{"y"}, desc: "Copy the project link"},
+		{action: actQR, keys: []string{"Q"}, desc: "Show a link as a QR code"},
+		{action: actCopySocial, keys: []string{"1", "2", "3"}, desc: "Copy a social link", label: "1-3"},
+		{action: actPickMatch, keys: []string{"1", "2", "3"}, desc: "Open a suggestion for a missing page", label: "1-3", page: routeNotFound},
+	}
+)
+
+// Actions that leave the visitor on the page they're on. Only these may
+// share a key with an easter egg's sequence or word before its last key:
+// anything else would walk off mid-egg, as → opening a project halfway
+// through the konami code.
+var staysPut = map[action]bool{
+	actUp: true, actDown: true, actNext: true, actLeft: true, actRight: true,
+}
+
+var keymaps = mustKeymaps(map[string][]binding{
+	"default": defaultNav,
+	"vim":     vimNav,
+	"emacs":   emacsNav,
+	"arrows":  arrowsNav,
+}, []string{"default", "vim", "emacs", "arrows"})
+
+// newKeymap builds a preset and checks it against the easter eggs. No key
+// may be bound twice on the same page, or to a key an egg takes on its
+// own, since the egg fires first and the binding would never be reached.
+// Keys partway through an egg's sequence or word may only be bound to
+// actions that stay put.
+func newKeymap(name string, nav []binding) (keymap, error) {
+	km := keymap{name: name, byKey: map[string]action{}, onPage: map[string]map[string]action{}}
+	km.bindings = append(append(km.bindings, nav...), sharedBindings...)
+
+	eggKeys, partway := map[string]string{}, map[string]string{}
+	for _, s := range easterEggs {
+		steps := s.Keys
+		for _, r := range s.Word {
+			steps = append(steps, string(r))
+		}
+		if len(steps) == 1 {
+			eggKeys[steps[0]] = s.ID
+			continue
+		}
+		for _, k := range steps[:max(0, len(steps)-1)] {
+			partway[k] = s.ID
+		}
+	}
+	for _, b := range km.bindings {
+		byKey := km.byKey
+		if b.page != "" {
+			if km.onPage[b.page] == nil {
+				km.onPage[b.page] = map[string]action{}
+			}
+			byKey = km.onPage[b.page]
+		}
+		for _, k := range b.keys {
+			if prev, dup := byKey[k]; dup {
+				return km, fmt.Errorf("keymap %q: %q is bound to both %s and %s", name, k, prev, b.action)
+			}
+			if id, ok := eggKeys[k]; ok {
+				return km, fmt.Errorf("keymap %q: %q for %s is taken by easter egg %q", name, k, b.action, id)
+			}
+			if id, ok := partway[k]; ok && !staysPut[b.action] {
+				return km, fmt.Errorf("keymap %q: %q for %s would leave the page partway through easter egg %q", name, k, b.action, id)
+			}
+			if k == "ctrl+c" {
+				return km, fmt.Errorf("keymap %q: ctrl+c always quits", name)
+			}
+			byKey[k] = b.action
+		}
+	}
+	return km, nil
+}
+
+func mustKeymaps(presets map[string][]binding, order []string) []keymap {
+	var kms []keymap
+	for _, name := range order {
+		km, err := newKeymap(name, presets[name])
+		if err != nil {
+			log.Fatalln(err)
+		}
+		kms = append(kms, km)
+	}
+	return kms
+}
+
+// keymapFor picks a preset from what the client told us: PORTFOLIO_KEYS
+// (default, vim, emacs or arrows, e.g. ssh -o SetEnv=PORTFOLIO_KEYS=vim),
+// or the default.
+func keymapFor(t terminal) keymap {
+	for _, km := range keymaps {
+		if strings.EqualFold(t.env["PORTFOLIO_KEYS"], km.name) {
+			return km
+		}
+	}
+	return keymaps[0]
+}
+
+func (k keymap) action(key string) action {
+	return k.byKey[key]
+}
+
+// actionOn is action for page, where that page's own bindings win.
+func (k keymap) actionOn(page, key string) action {
+	if a, ok := k.onPage[page][key]; ok {
+		return a
+	}
+	return k.byKey[key]
+}
+
+// Friendlier names for keys in help and hints
+var keyNames = map[string]string{
+	"up": "↑", "down": "↓", "left": "←", "right": "→",
+	" ": "spc", "backspace": "bksp",
+}
+
+func keyName(key string) string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+// key is the key hints show for a.
+func (k keymap) key(a action) string {
+	for _, b := range k.bindings {
+		if b.action == a {
+			return keyName(b.keys[0])
+		}
+	}
+	return ""
+}
+
+// hint is one "key what" pair of a hint line.
+type hint struct {
+	action action
+	text   string
+}
+
+// hints writes a hint line in this keymap's keys. Actions the preset
+// doesn't bind are left out; with compact, only the keys are shown.
+func (k keymap) hints(compact bool, hs ...hint) string {
+	var parts []string
+	for _, h := range hs {
+		key := k.key(h.action)
+		if key == "" {
+			continue
+		}
+		// Up and down, and left and right, always come as a pair
+		switch h.action {
+		case actUp:
+			key += k.key(actDown)
+		case actLeft:
+			key += "/" + k.key(actRight)
+		}
+		if !compact {
+			key += " " + h.text
+		}
+		parts = append(parts, key)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// keyLabel is every key for b, as help shows it.
+func (b binding) keyLabel() string {
+	if b.label != "" {
+		return b.label
+	}
+	names := make([]string, len(b.keys))
+	for i, key := range b.keys {
+		names[i] = keyName(key)
+	}
+	return strings.Join(names, " / ")
+}
+
+// cycleKeymap moves on to the next preset.
+func (m model) cycleKeymap() (model, tea.Cmd) {
+	for i, km := range keymaps {
+		if km.name == m.keys.name {
+			m.keys = keymaps[(i+1)%len(keymaps)]
+			break
+		}
+	}
+	return m.showToast("⌨ Keys: " + m.keys.name)
+}
+
+// eggHelp describes how an easter egg is found, for the help page.
+func eggHelp(s eggSpec) string {
+	switch {
+	case len(s.Keys) > 0:
+		names := make([]string, len(s.Keys))
+		for i, key := range s.Keys {
+			names[i] = keyName(key)
+		}
+		return strings.Join(names, "")
+	case s.Word != "":
+		return "type '" + s.Word + "'"
+	case s.Hours != nil:
+		return fmt.Sprintf("%02d:00 to %02d:00", s.Hours[0], s.Hours[1])
+	case s.Idle > 0:
+		return "sit idle"
+	}
+	return "?"
+}
+
+// renderKeyHelp lists the session's bindings, then the easter eggs. An
+// egg stays "???" until it has been found.
+func (m model) renderKeyHelp(width int) string {
+	type row struct{ key, desc string }
+	var rows []row
+	for _, b := range m.keys.bindings {
+		rows = append(rows, row{b.keyLabel(), b.desc})
+	}
+	rows = append(rows, row{})
+	for _, s := range easterEggs {
+		r := row{"???", "???"}
+		if m.discovered[s.ID] {
+			a, _ := findAchievement(s.ID)
+			r = row{eggHelp(s), a.Title}
+		}
+		rows = append(rows, r)
+	}
+
+	keyWidth := 0
+	for _, r := range rows {
+		keyWidth = max(keyWidth, lipgloss.Width(r.key))
+	}
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		if r.key == "" {
+			continue
+		}
+		pad := strings.Repeat(" ", keyWidth-lipgloss.Width(r.key))
+		lines[i] = pad + tagStyle.Render(r.key) + "   " + itemNormal.Render(r.desc)
+	}
+	return centerBlock(strings.Join(lines, "\n"), width) + "\n"
+}
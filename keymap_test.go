@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewKeymap(t *testing.T) {
+	tests := []struct {
+		name string
+		nav  []binding
+		err  string // empty when the preset is fine
+	}{
+		{"default", defaultNav, ""},
+		{"vim", vimNav, ""},
+		{"emacs", emacsNav, ""},
+		{"arrows", arrowsNav, ""},
+		{"bound twice", []binding{
+			{action: actUp, keys: []string{"up"}},
+			{action: actDown, keys: []string{"up"}},
+		}, `"up" is bound to both up and down`},
+		{"clashes with shared", []binding{
+			{action: actOpen, keys: []string{"t"}},
+		}, `"t" is bound to both open and typing`},
+		{"single key egg", []binding{
+			{action: actOpen, keys: []string{"m"}},
+		}, `"m" for open is taken by easter egg "matrix"`},
+		{"partway through a sequence", []binding{
+			{action: actOpen, keys: []string{"right"}},
+		}, `"right" for open would leave the page partway through easter egg "konami"`},
+		{"partway through a word", []binding{
+			{action: actBack, keys: []string{"h"}},
+		}, `"h" for back would leave the page partway through easter egg`},
+		{"staying put partway is fine", []binding{
+			{action: actLeft, keys: []string{"left", "h"}},
+			{action: actRight, keys: []string{"right", "l"}},
+		}, ""},
+		{"second to last key of a sequence", []binding{
+			{action: actHelp, keys: []string{"b"}},
+		}, `"b" for help would leave the page partway through easter egg "konami"`},
+		{"last letter of a word is fine", []binding{
+			{action: actOpen, keys: []string{"o"}},
+		}, ""},
+		{"ctrl+c", []binding{
+			{action: actQuit, keys: []string{"ctrl+c"}},
+		}, "ctrl+c always quits"},
+		{"page bindings can reuse keys", []binding{
+			{action: actOpen, keys: []string{"enter"}},
+			{action: actBack, keys: []string{"enter"}, page: pathHelp},
+		}, ""},
+		{"but not twice on one page", []binding{
+			{action: actOpen, keys: []string{"x"}, page: pathHelp},
+			{action: actBack, keys: []string{"x"}, page: pathHelp},
+		}, `"x" is bound to both open and back`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeymap(tt.name, tt.nav)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("newKeymap: %v", err)
+			case tt.err != "" && err == nil:
+				t.Errorf("newKeymap: no error, want %q", tt.err)
+			case tt.err != "" && !strings.Contains(err.Error(), tt.err):
+				t.Errorf("newKeymap: %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestKeymapActionOn(t *testing.T) {
+	km := keymaps[0]
+	tests := []struct {
+		page string
+		key  string
+		want action
+	}{
+		{pathHome, "1", actCopySocial},
+		{routeNotFound, "1", actPickMatch},
+		{routeNotFound, "esc", actBack},
+		{pathHome, "nope", ""},
+	}
+	for _, tt := range tests {
+		if got := km.actionOn(tt.page, tt.key); got != tt.want {
+			t.Errorf("actionOn(%s, %q) = %q, want %q", tt.page, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestKeymapHints(t *testing.T) {
+	tests := []struct {
+		preset string
+		want   string
+	}{
+		{"default", "←/→ switch · esc back"},
+		{"vim", "h/l switch · esc back"},
+		{"emacs", "ctrl+b/ctrl+f switch · ctrl+g back"},
+		{"arrows", "←/→ switch · esc back"},
+	}
+	for _, tt := range tests {
+		for _, km := range keymaps {
+			if km.name != tt.preset {
+				continue
+			}
+			if got := km.hints(false, hint{actLeft, "switch"}, hint{actBack, "back"}); got != tt.want {
+				t.Errorf("%s hints = %q, want %q", tt.preset, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- SCREEN LAYOUT ---
+
+// region is a rectangle on screen, in cells, that something was drawn in.
+type region struct {
+	id         string
+	x, y, w, h int
+}
+
+// layout records where View put things, so updates can find them again
+// (e.g. where the selected item is).
+type layout struct {
+	regions []region
+	hidden  int // rows the compact layout had no room for
+}
+
+// mark records that s is about to be written centered in width on the
+// current last line of b.
+func (l *layout) mark(id string, b *strings.Builder, s string, width int) {
+	w := lipgloss.Width(s)
+	col := 0
+	if w < width {
+		col = (width - w) / 2
+	}
+	l.markAt(id, b, col, s)
+}
+
+// markAt records that s is about to be written at column col of the
+// current last line of b, for things placed by hand.
+func (l *layout) markAt(id string, b *strings.Builder, col int, s string) {
+	row := strings.Count(b.String(), "\n")
+	l.regions = append(l.regions, region{id: id, x: col, y: row, w: lipgloss.Width(s), h: lipgloss.Height(s)})
+}
+
+// offset moves every region, e.g. from box content to screen coordinates.
+func (l layout) offset(dx, dy int) layout {
+	moved := layout{regions: make([]region, len(l.regions)), hidden: l.hidden}
+	for i, r := range l.regions {
+		r.x += dx
+		r.y += dy
+		moved.regions[i] = r
+	}
+	return moved
+}
+
+// add copies other's regions into l, moved by dx, dy.
+func (l *layout) add(other layout, dx, dy int) {
+	l.regions = append(l.regions, other.offset(dx, dy).regions...)
+}
+
+// window keeps the regions above row top, and those in the rows rows
+// after top+skip moved up by skip, as when scrolling a body starting at
+// top.
+func (l layout) window(top, skip, rows int) layout {
+	kept := layout{hidden: l.hidden}
+	for _, r := range l.regions {
+		switch {
+		case r.y < top:
+		case r.y >= top+skip && r.y < top+skip+rows:
+			r.y -= skip
+		default:
+			continue
+		}
+		kept.regions = append(kept.regions, r)
+	}
+	return kept
+}
+
+func (l layout) find(id string) (region, bool) {
+	for _, r := range l.regions {
+		if r.id == id {
+			return r, true
+		}
+	}
+	return region{}, false
+}
+
+// at finds what was drawn at screen cell x, y.
+func (l layout) at(x, y int) (region, bool) {
+	for _, r := range l.regions {
+		if x >= r.x && x < r.x+r.w && y >= r.y && y < r.y+r.h {
+			return r, true
+		}
+	}
+	return region{}, false
+}
+
+// Terminals wider than twoPaneMin columns show the list and the selected
+// item's detail side by side.
+const (
+	twoPaneMin       = 120
+	twoPaneMaxWidth  = 130
+	twoPaneListWidth = 46
+	twoPaneGap       = 2
+)
+
+func (m model) twoPane() bool {
+	return m.width > twoPaneMin && !m.compact()
+}
+
+// Below compactWidth by compactHeight the page drops the logo and extras
+// to fit, and below minWidth by minHeight it asks for a bigger terminal.
+// The compact layout always draws compactFrame rows (the border, the name,
+// the divider and the footer) and the hints below the body; the body
+// scrolls in what's left, which has to be at least compactMinBody rows.
+const (
+	compactWidth   = 44
+	compactHeight  = 20
+	compactFrame   = 5
+	compactMinBody = 4
+	minWidth       = 20
+	minHeight      = compactFrame + 1 + compactMinBody
+)
+
+func (m model) compact() bool {
+	return m.width > 0 && (m.width < compactWidth || m.height < compactHeight)
+}
+
+func (m model) tooSmall() bool {
+	return m.width > 0 && (m.width < minWidth || m.height < minHeight)
+}
+
+// fitCompact cuts the body of a compact page, the lines of content from
+// row top on, to what fits on screen above pinned, which stays put. The
+// rows shown follow the cursor on the home screen and the input on pages
+// that have one; elsewhere they scroll with m.scroll.
+func (m model) fitCompact(content string, top int, pinned []string, width, height int, lay layout) (string, layout) {
+	lines := strings.Split(content, "\n")
+	head, body := lines[:top], lines[top:]
+	for len(body) > 0 && strings.TrimSpace(ansi.Strip(body[0])) == "" {
+		body = body[1:]
+		lay = lay.window(top, 1, len(body))
+	}
+	for len(body) > 0 && strings.TrimSpace(ansi.Strip(body[len(body)-1])) == "" {
+		body = body[:len(body)-1]
+	}
+	// Wrap here what the box would wrap, so every row is counted
+	var wrapped []string
+	rowOf := make([]int, len(body))
+	for i, line := range body {
+		rowOf[i] = len(wrapped)
+		if textWidth(line) > width {
+			line = ansi.Wrap(line, width, "")
+		}
+		wrapped = append(wrapped, strings.Split(line, "\n")...)
+	}
+	for i, r := range lay.regions {
+		if r.y >= top && r.y-top < len(rowOf) {
+			lay.regions[i].y = top + rowOf[r.y-top]
+		}
+	}
+	body = wrapped
+	rows := max(height-compactFrame-len(pinned), 1)
+	start := m.scroll
+	switch {
+	case m.copyFallback != "" || m.hasInput():
+		start = len(body) - rows
+	case m.page() == pathHome:
+		if r, ok := lay.find(fmt.Sprintf("item:%d", m.cursor)); ok {
+			start = r.y - top - rows/2
+		}
+	}
+	start = max(0, min(start, len(body)-rows))
+	lay = lay.window(top, start, rows)
+	lay.hidden = max(len(body)-rows, 0)
+	body = body[start:min(start+rows, len(body))]
+	return strings.Join(append(append(head, body...), pinned...), "\n"), lay
+}
+
+// hasInput reports whether the page is typed into, so its input line
+// has to stay on screen.
+func (m model) hasInput() bool {
+	switch m.page() {
+	case pathTyping, pathGuestbook, pathShell, pathAsk:
+		return true
+	}
+	return false
+}
+
+// scrollBy scrolls the compact layout's body n rows, as far as there's
+// more to show.
+func (m model) scrollBy(n int) model {
+	_, lay := m.renderBoxed()
+	m.scroll = max(0, min(m.scroll+n, lay.hidden))
+	return m
+}
+
+// renderTooSmall asks for a bigger terminal, showing how far off it is.
+func (m model) renderTooSmall() string {
+	size := func(w, h int, ok bool) string {
+		style := lipgloss.NewStyle().Foreground(green)
+		if !ok {
+			style = style.Foreground(accent)
+		}
+		return style.Render(fmt.Sprintf("%d×%d", w, h))
+	}
+	msg := strings.Join([]string{
+		titleStyle.Render("Too small!"),
+		"",
+		socialText.Render("now  ") + size(m.width, m.height, false),
+		socialText.Render("need ") + size(minWidth, minHeight, true),
+		"",
+		hintStyle.Render("Please enlarge your terminal"),
+	}, "\n")
+	msg = lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(msg)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+}
+
+// browse follows the cursor moving. With the detail showing beside the
+// list, landing on an item counts as viewing it.
+func (m model) browse() (model, tea.Cmd) {
+	if m.page() == pathHome && m.twoPane() {
+		return m.markViewed(items[m.cursor])
+	}
+	return m, nil
+}
+
+// sprite is a small styled string drawn over a finished frame.
+type sprite struct {
+	x, y int
+	s    string
+}
+
+// overlay draws sprites over frame at their screen positions. The frame
+// keeps its styling on both sides of each sprite. Each line is rebuilt
+// once from the original, so sprites stacked on the same cell (as a
+// confetti burst starts out) don't copy the styling over and over.
+func overlay(frame string, sprites []sprite) string {
+	if len(sprites) == 0 {
+		return frame
+	}
+	lines := strings.Split(frame, "\n")
+	byLine := map[int][]sprite{}
+	for _, sp := range sprites {
+		if sp.y >= 0 && sp.y < len(lines) && sp.x >= 0 {
+			byLine[sp.y] = append(byLine[sp.y], sp)
+		}
+	}
+	for y, sps := range byLine {
+		sort.SliceStable(sps, func(i, j int) bool { return sps[i].x < sps[j].x })
+		line := lines[y]
+		lineWidth := ansi.StringWidth(line)
+		var b strings.Builder
+		col := 0
+		for _, sp := range sps {
+			// The first sprite in a cell wins
+			if sp.x < col {
+				continue
+			}
+			b.WriteString(cutCells(line, col, sp.x))
+			b.WriteString("\x1b[0m" + sp.s)
+			col = sp.x + ansi.StringWidth(sp.s)
+		}
+		if col < lineWidth {
+			b.WriteString(cutCells(line, col, lineWidth))
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cutCells is columns from to to of line, with its styling. Wide
+// characters cut in half become spaces, and a line that's too short is
+// padded, so what follows stays in its column.
+func cutCells(line string, from, to int) string {
+	s := ansi.Cut(line, from, to)
+	if w := ansi.StringWidth(s); w < to-from {
+		s += strings.Repeat(" ", to-from-w)
+	}
+	return s
+}
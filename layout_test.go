@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sized is a session on path in a width by height terminal.
+func sized(path string, width, height int) model {
+	m := initialModel()
+	m.width, m.height = width, height
+	switch path {
+	case pathAsk:
+		return m.openFAQ()
+	case pathShell:
+		return m.openShell()
+	case pathGuestbook:
+		return m.openGuestbook()
+	case pathTyping:
+		m.typing = newTypingTest()
+	}
+	return m.navigate(path)
+}
+
+// Every page of the compact layout fits the terminal, down to the
+// smallest one it's drawn in.
+func TestCompactFits(t *testing.T) {
+	paths := []string{pathHome, "/projects/pathhelm", "/about", pathHelp, pathAchievements,
+		pathGuestbook, pathTyping, pathShell, pathAsk, "/nope"}
+	sizes := [][2]int{{minWidth, minHeight}, {25, 12}, {30, 16}, {compactWidth - 1, compactHeight - 1}, {40, 30}, {80, compactHeight - 1}}
+	for _, size := range sizes {
+		for _, path := range paths {
+			w, h := size[0], size[1]
+			m := sized(path, w, h)
+			if !m.compact() || m.tooSmall() {
+				t.Fatalf("%dx%d: compact %v, too small %v", w, h, m.compact(), m.tooSmall())
+			}
+			for scroll := 0; scroll < 3; scroll++ {
+				view := m.View()
+				if got := lipgloss.Height(view); got > h {
+					t.Errorf("%s at %dx%d, scrolled %d: %d rows", path, w, h, m.scroll, got)
+				}
+				if got := lipgloss.Width(view); got > w {
+					t.Errorf("%s at %dx%d, scrolled %d: %d columns", path, w, h, m.scroll, got)
+				}
+				m = m.scrollBy(1)
+			}
+		}
+	}
+}
+
+func TestTooSmall(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          bool
+	}{
+		{minWidth, minHeight, false},
+		{minWidth - 1, minHeight, true},
+		{minWidth, minHeight - 1, true},
+		{80, 24, false},
+		{0, 0, false}, // no size yet
+	}
+	for _, tt := range tests {
+		m := sized(pathHome, tt.width, tt.height)
+		if got := m.tooSmall(); got != tt.want {
+			t.Errorf("tooSmall at %dx%d = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+// The compact detail is plain text: no card, words kept whole, and the
+// rest a scroll away.
+func TestCompactDetail(t *testing.T) {
+	m := sized("/projects/pathhelm", 25, 12)
+	seen := map[string]bool{}
+	var screens []string
+	for i := 0; i < 30; i++ {
+		view := ansi.Strip(m.View())
+		screens = append(screens, view)
+		for _, line := range strings.Split(view, "\n") {
+			for _, word := range strings.Fields(strings.Trim(line, " ║")) {
+				seen[strings.Trim(word, ".,")] = true
+			}
+		}
+		m = m.scrollBy(1)
+	}
+	if strings.Contains(screens[0], "╭") {
+		t.Errorf("compact detail is still boxed:\n%s", screens[0])
+	}
+	if !strings.Contains(screens[0], "PathHelm") {
+		t.Errorf("compact detail doesn't start at the title:\n%s", screens[0])
+	}
+	for _, word := range []string{"containerized", "validation", "analytics", "API"} {
+		if !seen[word] {
+			t.Errorf("%q never shows whole while scrolling", word)
+		}
+	}
+	if !strings.Contains(screens[len(screens)-1], "github.com") {
+		t.Errorf("scrolling doesn't reach the link:\n%s", screens[len(screens)-1])
+	}
+	if !strings.Contains(screens[0], "↑↓ more") || strings.Contains(screens[0], "back · ") {
+		t.Errorf("compact detail hints:\n%s", screens[0])
+	}
+
+	// Scrolling stops at either end
+	_, lay := m.renderBoxed()
+	if m.scroll != lay.hidden {
+		t.Errorf("scrolled %d, past the %d rows there are", m.scroll, lay.hidden)
+	}
+	m = m.scrollBy(-100)
+	if m.scroll != 0 {
+		t.Errorf("scrolled %d above the top", m.scroll)
+	}
+	// A new page starts at the top
+	m = m.scrollBy(2).back()
+	if m.scroll != 0 {
+		t.Errorf("home starts scrolled %d", m.scroll)
+	}
+}
+
+// On the home screen the body follows the cursor, and clicks land on
+// what's shown.
+func TestCompactListFollowsCursor(t *testing.T) {
+	m := sized(pathHome, minWidth, minHeight)
+	for i := range items {
+		m.cursor = i
+		view := ansi.Strip(m.View())
+		if !strings.Contains(view, "› ") {
+			t.Errorf("item %d selected off screen:\n%s", i, view)
+		}
+		_, lay := m.renderBoxed()
+		r, ok := lay.find(fmt.Sprintf("item:%d", i))
+		if !ok {
+			t.Errorf("item %d not marked", i)
+			continue
+		}
+		row := strings.Split(view, "\n")[r.y]
+		if !strings.Contains(row, ansi.Truncate(items[i].Title, 3, "")) {
+			t.Errorf("item %d marked on row %d, which is %q", i, r.y, row)
+		}
+	}
+}
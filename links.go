@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- LINKS ---
+
+// Terminals known to make OSC 8 hyperlinks clickable, by TERM_PROGRAM and
+// by TERM prefix. Everything else gets plain URLs, which nearly every
+// terminal can at least open on its own.
+var (
+	hyperlinkPrograms = map[string]bool{
+		"iTerm.app":    true,
+		"WezTerm":      true,
+		"vscode":       true,
+		"Hyper":        true,
+		"ghostty":      true,
+		"WarpTerminal": true,
+		"Tabby":        true,
+		"rio":          true,
+		"contour":      true,
+	}
+	hyperlinkTerms = []string{"xterm-kitty", "xterm-ghostty", "alacritty", "foot", "wezterm", "contour", "rio"}
+)
+
+// canHyperlink reports whether the terminal should understand OSC 8. Ones
+// that don't tend to print the escape as garbage, so unknown terminals
+// get footnotes.
+func (t terminal) canHyperlink() bool {
+	if hyperlinkPrograms[t.program] {
+		return true
+	}
+	for _, prefix := range hyperlinkTerms {
+		if strings.HasPrefix(t.term, prefix) {
+			return true
+		}
+	}
+	// Windows Terminal, and VTE based terminals since 0.50
+	if _, ok := t.env["WT_SESSION"]; ok {
+		return true
+	}
+	vte, _ := strconv.Atoi(t.env["VTE_VERSION"])
+	return vte >= 5000
+}
+
+// link makes text open url when clicked, where the terminal can do that.
+func (m model) link(url, text string) string {
+	if !m.links {
+		return text
+	}
+	return hyperlink(url, text)
+}
+
+// toggleLinks switches between clickable links and footnotes, for when
+// detection guessed wrong.
+func (m model) toggleLinks() (model, tea.Cmd) {
+	m.links = !m.links
+	if m.links {
+		return m.showToast("🔗 Clickable links on")
+	}
+	return m.showToast("Links shown as footnotes")
+}
+
+// footnote is the marker that points text at note n below it.
+func footnote(n int) string {
+	return hintStyle.Render(fmt.Sprintf("[%d]", n))
+}
+
+// renderFootnote writes out note n in full, for the terminal or the
+// visitor to pick up.
+func renderFootnote(n int, url string) string {
+	return footnote(n) + " " + socialText.Render(url)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// --- LOGO ---
+
+// The name drawn at the top of the page and the colors of an optional
+// left to right gradient across it. Set PORTFOLIO_NAME and
+// PORTFOLIO_LOGO_GRADIENT (comma separated #RRGGBB colors) to override.
+var (
+	logoName     = "Sajjad Aiyoob"
+	logoGradient []lipgloss.Color
+)
+
+// Most colors a gradient is split into, so a wide logo doesn't need an
+// escape sequence for every column
+const logoGradientSteps = 16
+
+func loadLogoConfig() {
+	if name := strings.TrimSpace(os.Getenv("PORTFOLIO_NAME")); name != "" {
+		logoName = name
+	}
+	covered := false
+	for _, f := range figFonts {
+		covered = covered || f.covers(logoName)
+	}
+	if !covered {
+		log.Printf("PORTFOLIO_NAME: no font has every character of %q, the logo is plain text", logoName)
+	}
+	if v := os.Getenv("PORTFOLIO_LOGO_GRADIENT"); v != "" {
+		colors, err := parseGradient(v)
+		if err != nil {
+			log.Fatalf("PORTFOLIO_LOGO_GRADIENT: %v", err)
+		}
+		logoGradient = colors
+	}
+}
+
+func parseGradient(v string) ([]lipgloss.Color, error) {
+	var colors []lipgloss.Color
+	for _, c := range strings.Split(v, ",") {
+		c = strings.TrimSpace(c)
+		if _, ok := parseHex(c); !ok {
+			return nil, fmt.Errorf("%q is not a #RRGGBB color", c)
+		}
+		colors = append(colors, lipgloss.Color(c))
+	}
+	if len(colors) < 2 {
+		return nil, fmt.Errorf("a gradient needs at least two colors")
+	}
+	return colors, nil
+}
+
+func parseHex(c string) (uint64, bool) {
+	if len(c) != 7 || c[0] != '#' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(c[1:], 16, 32)
+	return v, err == nil
+}
+
+// blendColor mixes a into b as t goes from 0 to 1.
+func blendColor(a, b lipgloss.Color, t float64) lipgloss.Color {
+	va, _ := parseHex(string(a))
+	vb, _ := parseHex(string(b))
+	mix := func(shift uint) uint64 {
+		ca := float64(va >> shift & 0xFF)
+		cb := float64(vb >> shift & 0xFF)
+		return uint64(ca + (cb-ca)*t)
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mix(16), mix(8), mix(0)))
+}
+
+// gradientPalette spreads n colors evenly along the stops.
+func gradientPalette(stops []lipgloss.Color, n int) []string {
+	palette := make([]string, n)
+	for i := range palette {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1) * float64(len(stops)-1)
+		}
+		seg := int(t)
+		if seg >= len(stops)-1 {
+			seg = len(stops) - 2
+		}
+		palette[i] = string(blendColor(stops[seg], stops[seg+1], t-float64(seg)))
+	}
+	return palette
+}
+
+// wrapLogo breaks name into lines at spaces so each fits width in font.
+// It fails if a single word is too wide.
+func wrapLogo(font *figFont, name string, width int) ([]string, bool) {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(name) {
+		if font.width(word) > width {
+			return nil, false
+		}
+		switch {
+		case line == "":
+			line = word
+		case font.width(line+" "+word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines, true
+}
+
+// logoArt sets name in the largest font that has all its characters and
+// fits width, one FIGlet line per wrapped line of text, each centered on
+// the widest. When no font does, the logo is the name as it is.
+func logoArt(name string, width int) []string {
+	var font *figFont
+	var lines []string
+	for _, f := range figFonts {
+		if !f.covers(name) {
+			continue
+		}
+		if wrapped, ok := wrapLogo(f, name, width); ok {
+			font, lines = f, wrapped
+			break
+		}
+	}
+	if font == nil {
+		return []string{name}
+	}
+
+	artWidth := 0
+	for _, line := range lines {
+		if w := font.width(line); w > artWidth {
+			artWidth = w
+		}
+	}
+	var art []string
+	for _, line := range lines {
+		pad := strings.Repeat(" ", (artWidth-font.width(line))/2)
+		for _, row := range font.render(line) {
+			art = append(art, pad+row)
+		}
+	}
+	return art
+}
+
+// renderLogo draws the logo for a content area width columns wide, part
+// way through any effects in fx.
+func renderLogo(width int, fx logoFX, profile termenv.Profile) string {
+	art := logoArt(logoName, width)
+	if len(logoGradient) < 2 && !fx.active() {
+		return centerBlock(logoStyle.Render(strings.Join(art, "\n")), width)
+	}
+
+	grid := make([][]rune, len(art))
+	artWidth := 0
+	for i, row := range art {
+		grid[i] = []rune(row)
+		if len(grid[i]) > artWidth {
+			artWidth = len(grid[i])
+		}
+	}
+	steps := logoGradientSteps
+	if artWidth < steps {
+		steps = artWidth
+	}
+	stops := logoGradient
+	if len(stops) < 2 {
+		stops = []lipgloss.Color{accent, accent}
+	}
+	palette := gradientPalette(stops, steps)
+	// Runs of the same color share an index, so they share an escape
+	same := make([]int, steps)
+	for i, c := range palette {
+		if g := fx.glow(i, steps); g > 0 {
+			palette[i] = string(blendColor(lipgloss.Color(c), shimmerGlow, g))
+		}
+		same[i] = i
+		if i > 0 && palette[i] == palette[i-1] {
+			same[i] = same[i-1]
+		}
+	}
+	colored := renderCells(profile, artWidth, len(grid), palette, func(x, y int) (rune, int) {
+		if x >= len(grid[y]) || grid[y][x] == ' ' || !fx.revealed(x, y, artWidth, len(grid)) {
+			return ' ', -1
+		}
+		return grid[y][x], same[x*steps/artWidth]
+	})
+	// Pad to the full width so the block doesn't shift while it reveals
+	block := lipgloss.NewStyle().Width(artWidth).Render(strings.TrimSuffix(colored, "\n"))
+	return centerBlock(block, width)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogoArt(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		rows  int      // the font's height times the wrapped lines
+		plain []string // the exact art, for one row fonts
+	}{
+		{"Sajjad Aiyoob", 200, 6, nil},
+		{"Sajjad Aiyoob", 60, 12, nil},
+		{"Hi!", 200, 6, nil},
+		// No block font draws '#' or '@', so they'd vanish from one
+		{"C#", 200, 1, []string{"C#"}},
+		{"me@home", 200, 1, []string{"me@home"}},
+		// Nothing draws ë, so the name stays as written
+		{"Zoë", 200, 1, []string{"Zoë"}},
+		{"Zoë Zoë", 3, 1, []string{"Zoë Zoë"}},
+	}
+	for _, tt := range tests {
+		art := logoArt(tt.name, tt.width)
+		if len(art) != tt.rows {
+			t.Errorf("logoArt(%q, %d) has %d rows, want %d", tt.name, tt.width, len(art), tt.rows)
+			continue
+		}
+		if tt.plain != nil && !reflect.DeepEqual(art, tt.plain) {
+			t.Errorf("logoArt(%q, %d) = %q, want %q", tt.name, tt.width, art, tt.plain)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"os"
@@ -16,13 +17,17 @@ import (
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	wb "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/termenv"
+	"github.com/rivo/uniseg"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // --- 1. DATA & CONTENT ---
 
 type Item struct {
 	Title       string
+	Slug        string // its address, e.g. /projects/pathhelm; keep it stable
 	Category    string
 	Description string
 	TechStack   string
@@ -35,6 +40,7 @@ var items = []Item{
 	// PROJECTS
 	{
 		Title:     "zenRoute",
+		Slug:      "zenroute",
 		Category:  "projects",
 		Tag:       "IoT",
 		Icon:      "◈",
@@ -45,6 +51,7 @@ var items = []Item{
 	},
 	{
 		Title:     "PathHelm",
+		Slug:      "pathhelm",
 		Category:  "projects",
 		Tag:       "API",
 		Icon:      "⬡",
@@ -55,6 +62,7 @@ var items = []Item{
 	},
 	{
 		Title:     "Chat Server",
+		Slug:      "chat-server",
 		Category:  "projects",
 		Tag:       "Backend",
 		Icon:      "◉",
@@ -67,6 +75,7 @@ var items = []Item{
 	// ABOUT
 	{
 		Title:     "About",
+		Slug:      "about",
 		Category:  "about",
 		Tag:       "Profile",
 		Icon:      "●",
@@ -91,6 +100,9 @@ var socials = []Social{
 	{Icon: "✉ ", Name: "Email", URL: "sajaiyoobofficial@gmail.com", Link: "mailto:sajaiyoobofficial@gmail.com"},
 }
 
+// The line under the name
+var tagline = "Backend Developer · Cloud Enthusiast · DevOps"
+
 // Easter egg quotes
 var quotes = []string{
 	"\"First, solve the problem. Then, write the code.\"",
@@ -103,12 +115,167 @@ var quotes = []string{
 	"\"sudo make me a sandwich\" - xkcd",
 }
 
-// Easter egg secrets
-var easterEggHints = []string{
-	"Try typing 'hello'...",
-	"Press 's' for a surprise...",
-	"The konami code works here...",
-	"Press 'c' for confetti...",
+// FAQ is one answer in the ask view. Also lists other ways people ask
+// it, and Follow the IDs of questions worth suggesting next.
+type FAQ struct {
+	ID       string
+	Question string
+	Also     []string
+	Answer   string
+	Follow   []string
+}
+
+var faqs = []FAQ{
+	{
+		ID:       "stack",
+		Question: "What's your stack?",
+		Also:     []string{"What technologies do you use?", "Which languages and tools do you know?", "What do you build with?"},
+		Answer:   "Mostly Python on the backend (FastAPI, WebSockets), with Redis and Supabase for data, Docker for everything, and React when there's a frontend to build.",
+		Follow:   []string{"projects", "devops", "hire"},
+	},
+	{
+		ID:       "relocation",
+		Question: "Are you open to relocation?",
+		Also:     []string{"Would you move for a job?", "Can you relocate abroad?", "Do you work remotely?", "Remote or onsite?"},
+		Answer:   "Happy to talk about it: remote, hybrid or relocating can all work for the right role. Tell me what you have in mind.",
+		Follow:   []string{"hire", "contact"},
+	},
+	{
+		ID:       "hire",
+		Question: "Are you available for hire?",
+		Also:     []string{"Are you looking for a job?", "Can we hire you?", "Are you open to work?", "Are you open to internships or new roles?"},
+		Answer:   "I am! I'm after backend, cloud and DevOps roles. Email sajaiyoobofficial@gmail.com and let's talk.",
+		Follow:   []string{"contact", "relocation", "stack"},
+	},
+	{
+		ID:       "contact",
+		Question: "How can I contact you?",
+		Also:     []string{"What's your email?", "Where can I reach you?", "Are you on LinkedIn or GitHub?"},
+		Answer:   "Email sajaiyoobofficial@gmail.com, or find me at github.com/KingSajxxd and linkedin.com/in/sajjad-aiyoob.",
+		Follow:   []string{"hire", "projects"},
+	},
+	{
+		ID:       "projects",
+		Question: "What have you built?",
+		Also:     []string{"Show me your projects", "What are you working on?", "What's your best project?"},
+		Answer:   "zenRoute, a smart transport safety platform I lead a team on; PathHelm, a containerized API gateway; and a real-time WebSocket chat server. Each has a page here.",
+		Follow:   []string{"zenroute", "pathhelm", "stack"},
+	},
+	{
+		ID:       "zenroute",
+		Question: "What is zenRoute?",
+		Also:     []string{"Tell me about the transport safety platform", "What's your IoT project?", "Do you lead a team?"},
+		Answer:   "Sri Lanka's first smart transport safety platform. I lead a team of six on it: IoT hardware, ML-based ETA predictions and a backend built to scale, on FastAPI, Supabase and Docker.",
+		Follow:   []string{"pathhelm", "stack"},
+	},
+	{
+		ID:       "pathhelm",
+		Question: "What is PathHelm?",
+		Also:     []string{"Tell me about your API gateway", "Do you have open source work?"},
+		Answer:   "A developer-first API gateway in Python and Redis: rate limiting, API key checks and traffic logs, with AI-powered analytics on top. The code is on GitHub.",
+		Follow:   []string{"zenroute", "devops"},
+	},
+	{
+		ID:       "education",
+		Question: "Where do you study?",
+		Also:     []string{"What's your education?", "Are you a student?", "What degree are you doing?", "Which university?"},
+		Answer:   "I'm a Software Engineering undergraduate at IIT/Westminster, focused on backend development and cloud solutions.",
+		Follow:   []string{"hire", "projects"},
+	},
+	{
+		ID:       "devops",
+		Question: "Do you do DevOps and cloud?",
+		Also:     []string{"Do you know Docker?", "Can you deploy and run infrastructure?", "What cloud experience do you have?"},
+		Answer:   "Yes, it's where I'm headed. Everything I build ships in containers, and I like owning a system from the code to where it runs. I don't just write code; I ship systems.",
+		Follow:   []string{"stack", "hire"},
+	},
+}
+
+// Splash script, see splash.go for the format
+var splashScript = `
+type speed=35 | Initializing portfolio...
+cmd delay=250 | ./portfolio --load projects
+progress speed=700 color=accent | > Loading projects
+cmd delay=150 | ./portfolio --connect
+progress speed=500 color=cyan | > Connecting systems
+type delay=200 | > Welcome, visitor.
+`
+
+// eggSpec declares an easter egg. Give it exactly one trigger (Keys,
+// Word, Hours or Idle) and at least one effect (Quote, View, Animation).
+// Hints feed the footer, from vague to spelling it out.
+type eggSpec struct {
+	ID    string
+	Hints []string
+	// Triggers
+	Keys  []string      // key sequence, a single key works too
+	Word  string        // typed anywhere outside text inputs
+	Hours []int         // local hour range [from, to), once per session
+	Idle  time.Duration // no input for this long
+	// Effects
+	Quote       string
+	RandomQuote bool
+	View        string // "matrix", "help", "typing"
+	Animation   string // "confetti"
+	// Command, if set, lists the egg in the command palette once found
+	Command string
+}
+
+var konamiCode = []string{"up", "up", "down", "down", "left", "right", "left", "right", "b", "a"}
+
+var easterEggs = []eggSpec{
+	{
+		ID: "konami", Keys: konamiCode, View: "matrix",
+		Hints: []string{"Some codes never get old...", "Gamers from the 80s know the way in...", "↑ ↑ ↓ ↓ ← → ← → b a"},
+	},
+	{
+		ID: "matrix", Keys: []string{"m"}, View: "matrix", Command: "Enter the Matrix",
+		Hints: []string{"There is no spoon...", "Follow the white rabbit...", "Press 'm' to enter the Matrix"},
+	},
+	{
+		ID: "hello", Word: "hello", Quote: "👋 Hello there, curious one! You found a secret!",
+		Hints: []string{"Manners matter around here...", "A greeting goes a long way...", "Try typing 'hello'..."},
+	},
+	{
+		ID: "hire", Word: "hire", Quote: "💼 I'm available! Email: sajaiyoobofficial@gmail.com",
+		Hints: []string{"Looking for an engineer?", "Recruiters have a magic word...", "Try typing 'hire'..."},
+	},
+	{
+		ID: "surprise", Keys: []string{"s"}, Quote: "🐍 Ssssurprise! You found me!", Animation: "confetti", Command: "A little surprise",
+		Hints: []string{"Something is hiding in plain sight...", "Ssssomething slithers nearby...", "Press 's' for a surprise..."},
+	},
+	{
+		ID: "confetti", Keys: []string{"c"}, Animation: "confetti", Command: "Throw confetti",
+		Hints: []string{"Feeling festive?", "Every party needs a little confetti...", "Press 'c' for confetti..."},
+	},
+	{
+		ID: "night-owl", Hours: []int{0, 5}, Quote: "🦉 Up past midnight? Same here. Welcome, night owl.",
+		Hints: []string{"Some secrets only come out at night...", "Come back after midnight...", "Visit between midnight and 5am..."},
+	},
+	{
+		ID: "idle", Idle: 45 * time.Second, RandomQuote: true,
+		Hints: []string{"Patience is a virtue...", "Good things come to those who wait...", "Sit still on the home screen for a bit..."},
+	},
+}
+
+// Achievements. Every easter egg ID needs one; the rest are milestones.
+type achievement struct {
+	ID    string
+	Title string
+	Desc  string
+}
+
+var achievements = []achievement{
+	{ID: "konami", Title: "Old School", Desc: "Entered the konami code"},
+	{ID: "matrix", Title: "Red Pill", Desc: "Took the shortcut into the Matrix"},
+	{ID: "hello", Title: "Polite Visitor", Desc: "Said hello"},
+	{ID: "hire", Title: "Recruiter Mode", Desc: "Asked about hiring"},
+	{ID: "surprise", Title: "Snake Charmer", Desc: "Found the surprise"},
+	{ID: "confetti", Title: "Party Starter", Desc: "Threw some confetti"},
+	{ID: "night-owl", Title: "Night Owl", Desc: "Visited after midnight"},
+	{ID: "idle", Title: "Daydreamer", Desc: "Stayed idle long enough to get a quote"},
+	{ID: "all-projects", Title: "Completionist", Desc: "Viewed every project"},
+	{ID: "guestbook", Title: "Left a Mark", Desc: "Signed the guestbook"},
 }
 
 // --- 2. STYLES ---
@@ -193,10 +360,6 @@ var (
 			Italic(true)
 
 	// Splash styles
-	splashStyle = lipgloss.NewStyle().
-			Foreground(green).
-			Bold(true)
-
 	cursorStyle = lipgloss.NewStyle().
 			Foreground(accent).
 			Bold(true)
@@ -209,24 +372,35 @@ var (
 
 // --- 3. MODEL ---
 
-const (
-	ViewSplash = iota
-	ViewList
-	ViewDetail
-	ViewMatrix // Easter egg
-	ViewHelp   // New help view
-)
-
 // Messages for animations
 type tickMsg time.Time
 type blinkMsg struct{}
 
+// clockMsg is a slow heartbeat for time based triggers
+type clockMsg time.Time
+
 func tickCmd() tea.Cmd {
 	return tea.Tick(50*time.Millisecond, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// tick starts the animation loop unless it is already running, so
+// animations never end up with two loops and double speed.
+func (m *model) tick() tea.Cmd {
+	if m.ticking {
+		return nil
+	}
+	m.ticking = true
+	return tickCmd()
+}
+
+func clockCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return clockMsg(t)
+	})
+}
+
 func blinkCmd() tea.Cmd {
 	return tea.Tick(400*time.Millisecond, func(t time.Time) tea.Msg {
 		return blinkMsg{}
@@ -235,53 +409,126 @@ func blinkCmd() tea.Cmd {
 
 type model struct {
 	cursor int
-	view   int
+	nav    router // which page is showing, and the way back
 	width  int
 	height int
 	// Splash animation
-	splashText  string
-	splashIndex int
-	blinkCount  int
-	showCursor  bool
-	splashDone  bool
+	splash     splashPlayer
+	blinkCount int
+	showCursor bool
+	splashDone bool
 	// Easter eggs
-	konamiIndex    int
-	matrixRain     [][]rune
-	matrixTick     int
+	eggs        eggState
+	discovered  map[string]bool
+	lastInput   time.Time
+	ticking     bool
+	effects     []Effect
+	effectIndex int
+	// Idle handling
+	saver    Effect
+	farewell bool
+	// Logo and border effects, off for reduced motion or few colors
+	motion         bool
+	colors         termenv.Profile
+	fx             logoFX
 	showQuote      bool
 	currentQuote   string
 	typedBuffer    string
-	showConfetti   bool
-	confettiTick   int
+	confetti       []particle
 	showSnake      bool
 	snakeX         int
 	snakeY         int
 	snakeDir       int
 	showHint       bool
-	hintIndex      int
+	sessionStart   time.Time
+	hintSeed       int64
 	easterEggTimer int
+	// Typing test
+	typing     typingTest
+	typingBest typingResult
+	// Achievements and guestbook
+	viewed       map[string]bool
+	toast        string
+	toastID      int
+	guestInput   lineInput
+	guestEntries []guestbookEntry // shown to everyone
+	guestPick    int              // entry a moderator would hide
+	// Visitor identity, empty for keyless sessions
+	user        string
+	fingerprint string
+	store       *visitorStore
+	// The session's output, shared with the renderer, for escapes that
+	// go around it, and what we know of the terminal on the other end
+	out          io.Writer
+	term         terminal
+	copyFallback string
+	links        bool // OSC 8 hyperlinks, or footnotes when off
+	scroll       int  // rows scrolled off the top of a compact page
+	glyphs       glyphSet
+	keys         keymap
+	// QR codes for the open item and the socials
+	qrTargets []qrTarget
+	qrIndex   int
+	// Command palette, and the commands run from it lately
+	palette palette
+	recent  []string
+	shell   shell
+	faq     faqChat
 }
 
-var splashFullText = "Initializing portfolio...\n> Loading projects\n> Connecting systems\n> Welcome, visitor."
-var konamiCode = []string{"up", "up", "down", "down", "left", "right", "left", "right", "b", "a"}
-
 func initialModel() model {
 	return model{
-		cursor:      0,
-		view:        ViewSplash,
-		splashText:  "",
-		splashIndex: 0,
-		blinkCount:  0,
-		showCursor:  true,
-		konamiIndex: 0,
-		typedBuffer: "",
-		snakeX:      10,
-		snakeY:      5,
+		cursor:     0,
+		nav:        newRouter(pathSplash),
+		blinkCount: 0,
+		showCursor: true,
+		eggs:       newEggState(),
+		discovered: map[string]bool{},
+		viewed:     map[string]bool{},
+		lastInput:  time.Now(),
+		motion:     true,
+		colors:     termenv.TrueColor,
+		glyphs:     unicodeGlyphs,
+		keys:       keymaps[0],
+		// Hints are a pure function of the seed and session age
+		sessionStart: time.Now(),
+		hintSeed:     time.Now().UnixNano(),
+		typedBuffer:  "",
+		snakeX:       10,
+		snakeY:       5,
+	}
+}
+
+// newSessionModel builds the model for one SSH session, restoring
+// anything we remember about the visitor's key.
+func newSessionModel(s ssh.Session, st *visitorStore) model {
+	m := initialModel()
+	m.user = printable(s.User())
+	m.fingerprint = fingerprint(s)
+	m.store = st
+	m.out = &syncWriter{w: s}
+	m.term = sessionTerminal(s)
+	m.links = m.term.canHyperlink()
+	m.glyphs = glyphsFor(m.term)
+	m.keys = keymapFor(m.term)
+	m.motion = !m.term.wantsStill()
+	m = m.deepLink(s.Command())
+	m.colors = wb.MakeRenderer(s).ColorProfile()
+	rec := st.Get(m.fingerprint)
+	m.typingBest = rec.TypingBest
+	for id := range rec.Achievements {
+		m.discovered[id] = true
 	}
+	m.viewed = rec.Viewed
+	return m
 }
 
 func (m model) Init() tea.Cmd {
-	return tickCmd()
+	if m.page() != pathSplash {
+		linked := func() tea.Msg { return linkedMsg{} }
+		return tea.Batch(linked, clockCmd())
+	}
+	return tea.Batch(splashAfter(0), clockCmd())
 }
 
 // --- 4. UPDATE ---
@@ -291,181 +538,302 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		// Initialize matrix rain
-		if m.matrixRain == nil {
-			m.matrixRain = make([][]rune, msg.Width)
-			for i := range m.matrixRain {
-				m.matrixRain[i] = make([]rune, msg.Height)
-			}
+		// Effects follow the window
+		if m.page() == pathEffects {
+			m.effects[m.effectIndex].Init(m.effectSize())
+		}
+		if m.saver != nil {
+			m.saver.Init(m.width, m.height)
 		}
 
 	case tickMsg:
-		if m.view == ViewSplash && !m.splashDone {
-			if m.splashIndex < len(splashFullText) {
-				m.splashText += string(splashFullText[m.splashIndex])
-				m.splashIndex++
-				return m, tickCmd()
+		// Whatever still animates asks for the next tick below
+		m.ticking = false
+		if m.saver != nil {
+			m.saver.Step()
+			return m, m.tick()
+		}
+		if m.page() == pathEffects {
+			m.effects[m.effectIndex].Step()
+			return m, m.tick()
+		}
+
+		more := false
+		if m.fx.active() {
+			if m.logoVisible() {
+				more = m.fx.step()
 			} else {
-				m.splashDone = true
-				return m, blinkCmd()
+				m.fx = logoFX{}
 			}
 		}
-		if m.view == ViewMatrix {
-			m.matrixTick++
-			// Update matrix rain
-			for i := range m.matrixRain {
-				if rand.Intn(10) < 3 {
-					for j := len(m.matrixRain[i]) - 1; j > 0; j-- {
-						m.matrixRain[i][j] = m.matrixRain[i][j-1]
-					}
-					chars := "アイウエオカキクケコサシスセソタチツテト0123456789ABCDEF"
-					m.matrixRain[i][0] = rune(chars[rand.Intn(len(chars))])
-				}
-			}
-			return m, tickCmd()
+		if len(m.confetti) > 0 {
+			m.confetti = stepConfetti(m.confetti, m.height)
+			more = true
 		}
 
 		// Auto-hide easter eggs after 10 seconds
-		if m.view == ViewList && (m.showQuote || m.showConfetti) {
+		if m.page() == pathHome && m.showQuote {
 			m.easterEggTimer++
 			if m.easterEggTimer > 200 { // 10 seconds (200 * 50ms)
 				m.showQuote = false
-				m.showConfetti = false
 				m.easterEggTimer = 0
-				return m, nil
+			} else {
+				more = true
+			}
+		}
+		if more {
+			return m, m.tick()
+		}
+
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+
+	case farewellDoneMsg:
+		return m, tea.Quit
+
+	case clockMsg:
+		idle := time.Since(m.lastInput)
+		var idleCmd tea.Cmd
+		m, idleCmd = m.checkIdle(idle)
+		if idleCmd != nil {
+			return m, tea.Batch(idleCmd, clockCmd())
+		}
+
+		// Time of day and idle eggs only make sense on the home screen
+		if m.page() == pathHome {
+			ev := eggEvent{now: time.Time(msg), idle: idle}
+			if egg := easterEggRegistry.Match(ev, m.eggs); egg != nil {
+				var cmd tea.Cmd
+				m, cmd = m.fireEgg(egg)
+				return m, tea.Batch(cmd, clockCmd())
+			}
+		}
+		return m, tea.Batch(m.shimmerDue(time.Time(msg)), clockCmd())
+
+	case linkedMsg:
+		return m.arriveLinked()
+
+	case splashMsg:
+		if m.page() == pathSplash && !m.splashDone {
+			if wait, more := m.splash.advance(); more {
+				return m, splashAfter(wait)
 			}
-			return m, tickCmd()
+			m.splashDone = true
+			return m, blinkCmd()
 		}
 
 	case blinkMsg:
-		if m.view == ViewSplash && m.splashDone {
+		if m.page() == pathSplash && m.splashDone {
 			m.showCursor = !m.showCursor
 			m.blinkCount++
 			if m.blinkCount >= 6 { // 3 full blinks
-				m.view = ViewList
-				return m, nil
+				m.nav = newRouter(pathHome)
+				return m, m.bootReveal()
 			}
 			return m, blinkCmd()
 		}
 
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		m.lastInput = time.Now()
+		if m.farewell {
+			return m, nil
+		}
+		if m.saver != nil {
+			m.saver = nil
+			return m, nil
+		}
+		switch m.page() {
+		case pathHome, routeItem, pathHelp, pathAchievements, routeNotFound:
+			return m.updateMouse(msg)
+		}
+
 	case tea.KeyMsg:
 		key := msg.String()
+		m.lastInput = time.Now()
 
-		// Skip splash on any key
-		if m.view == ViewSplash {
-			m.view = ViewList
+		if m.farewell {
+			return m, nil
+		}
+		// Wake from the screensaver; the key only wakes
+		if m.saver != nil {
+			m.saver = nil
 			return m, nil
 		}
 
-		// Exit matrix mode
-		if m.view == ViewMatrix {
-			if key == "esc" || key == "q" {
-				m.view = ViewList
-			}
-			return m, tickCmd()
+		// Skip splash on any key
+		if m.page() == pathSplash {
+			m.nav = newRouter(pathHome)
+			return m, m.bootReveal()
 		}
 
-		// Konami code detection
-		if key == konamiCode[m.konamiIndex] {
-			m.konamiIndex++
-			if m.konamiIndex == len(konamiCode) {
-				m.konamiIndex = 0
-				m.view = ViewMatrix
-				return m, tickCmd()
-			}
-		} else {
-			m.konamiIndex = 0
+		// History works from anywhere, even while typing
+		switch key {
+		case "alt+left":
+			return m.back(), nil
+		case "alt+right":
+			return m.forward(), nil
 		}
 
-		// Track typed characters for easter eggs
-		if len(key) == 1 {
-			m.typedBuffer += key
-			if len(m.typedBuffer) > 10 {
-				m.typedBuffer = m.typedBuffer[1:]
-			}
-			// Check for secret words
-			if strings.HasSuffix(m.typedBuffer, "hello") {
-				m.showQuote = true
-				m.currentQuote = "👋 Hello there, curious one! You found a secret!"
-				m.typedBuffer = ""
-				m.easterEggTimer = 0
-				return m, tickCmd()
-			}
-			if strings.HasSuffix(m.typedBuffer, "hire") {
-				m.showQuote = true
-				m.currentQuote = "💼 I'm available! Email: sajaiyoobofficial@gmail.com"
-				m.typedBuffer = ""
-				m.easterEggTimer = 0
-				return m, tickCmd()
+		// The palette takes every key while it's open. ctrl+k opens it
+		// from anywhere, : wherever it isn't being typed.
+		if m.palette.open {
+			return m.updatePalette(msg)
+		}
+		if m.keys.action(key) == actPalette && (msg.Type != tea.KeyRunes || !m.takesText()) {
+			return m.openPalette(), nil
+		}
+
+		// Effects gallery
+		if m.page() == pathEffects {
+			return m.updateEffects(msg)
+		}
+
+		// Text entry views own the keyboard, including letters like q
+		if m.page() == pathTyping {
+			return m.updateTyping(msg)
+		}
+		if m.page() == pathGuestbook {
+			return m.updateGuestbook(msg)
+		}
+		if m.page() == pathShell {
+			return m.updateShell(msg)
+		}
+		if m.page() == pathAsk {
+			return m.updateFAQ(msg)
+		}
+		if m.page() == pathQR {
+			return m.updateQR(msg)
+		}
+
+		// Track typed characters for easter eggs. Work in runes: a single
+		// KeyMsg can carry several characters, or one multi-byte one.
+		if msg.Type == tea.KeyRunes && !msg.Paste {
+			typed := []rune(m.typedBuffer + string(msg.Runes))
+			if len(typed) > 10 {
+				typed = typed[len(typed)-10:]
 			}
+			m.typedBuffer = string(typed)
 		}
 
-		switch key {
-		case "ctrl+c":
+		// Key sequences and secret words
+		ev := eggEvent{key: key, typed: m.typedBuffer, now: m.lastInput}
+		if egg := easterEggRegistry.Match(ev, m.eggs); egg != nil {
+			return m.fireEgg(egg)
+		}
+
+		if key == "ctrl+c" {
 			return m, tea.Quit
+		}
 
-		case "q":
-			if m.view == ViewList {
+		switch m.keys.actionOn(m.page(), key) {
+		case actQuit:
+			if m.page() == pathHome {
 				return m, tea.Quit
 			}
-			m.view = ViewList
+			m = m.navigate(pathHome)
 
-		case "up", "k":
-			if m.view == ViewList && m.cursor > 0 {
+		case actUp:
+			if m.page() == pathHome && m.cursor > 0 {
 				m.cursor--
+				return m.browse()
+			}
+			if m.page() != pathHome {
+				m = m.scrollBy(-1)
 			}
-		case "down", "j":
-			if m.view == ViewList && m.cursor < len(items)-1 {
+		case actDown:
+			if m.page() == pathHome && m.cursor < len(items)-1 {
 				m.cursor++
+				return m.browse()
+			}
+			if m.page() != pathHome {
+				m = m.scrollBy(1)
 			}
 
-		case "enter", " ":
-			if m.view == ViewList {
-				m.view = ViewDetail
+		case actOpen:
+			if m.page() == pathHome {
+				m = m.open(m.cursor)
+				return m.markViewed(items[m.cursor])
 			}
 
-		case "esc", "backspace":
-			if m.view == ViewDetail || m.view == ViewHelp {
-				m.view = ViewList
+		case actBack:
+			if m.page() != pathHome {
+				m = m.back()
 			}
 			m.showQuote = false
-			m.showConfetti = false
+			m.confetti = nil
 			m.showHint = false
+			m.copyFallback = ""
 
-		case "?":
+		case actHelp:
 			// Toggle help view
-			if m.view == ViewHelp {
-				m.view = ViewList
+			if m.page() == pathHelp {
+				m = m.back()
 			} else {
-				m.view = ViewHelp
+				m = m.navigate(pathHelp)
 			}
 
-		case "s":
-			// Surprise easter egg
-			m.showQuote = true
-			m.currentQuote = "🐍 Ssssurprise! You found me!"
-			m.showConfetti = true
-			m.confettiTick = 0
-			m.easterEggTimer = 0
-			return m, tickCmd()
-
-		case "c":
-			// Confetti easter egg
-			m.showConfetti = !m.showConfetti
-			if m.showConfetti {
-				m.confettiTick = 0
-				m.easterEggTimer = 0
-				return m, tickCmd()
-			}
+		case actPrev:
+			m = m.back()
+		case actForward:
+			m = m.forward()
 
-		case "m":
-			// Matrix mode shortcut (easier than konami)
-			m.view = ViewMatrix
-			return m, tickCmd()
-
-		case "tab":
+		case actNext:
 			// Cycle through items faster
 			m.cursor = (m.cursor + 1) % len(items)
+			return m.browse()
+
+		case actTyping:
+			// Typing speed test on a random quote
+			m = m.navigate(pathTyping)
+			m.typing = newTypingTest()
+
+		case actAchievements:
+			m = m.navigate(pathAchievements)
+
+		case actGuestbook:
+			m = m.openGuestbook()
+
+		case actMotion:
+			return m.toggleMotion()
+
+		case actLinks:
+			return m.toggleLinks()
+
+		case actGlyphs:
+			return m.cycleGlyphs()
+
+		case actKeymap:
+			return m.cycleKeymap()
+
+		case actCopyLink:
+			if m.page() == routeItem || (m.page() == pathHome && m.twoPane()) {
+				return m.copyLink()
+			}
+
+		case actShell:
+			m = m.openShell()
+
+		case actAsk:
+			m = m.openFAQ()
+
+		case actQR:
+			if m.page() == pathHome || m.page() == routeItem {
+				m = m.openQR()
+			}
+
+		case actCopySocial:
+			// Copy a social link from the home screen
+			if i := int(key[0] - '1'); m.page() == pathHome && i < len(socials) {
+				return m.copyToClipboard(socials[i].URL, socials[i].Name)
+			}
+
+		case actPickMatch:
+			return m.openMatch(int(key[0] - '1'))
 		}
 	}
 	return m, nil
@@ -478,11 +846,20 @@ func hyperlink(url, text string) string {
 	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
 }
 
+// textWidth is how many columns line takes on screen, leaving out escape
+// sequences. It goes a grapheme cluster at a time, so emoji, flags and
+// joined sequences count as the two columns terminals give them rather
+// than two per rune.
+func textWidth(line string) int {
+	return uniseg.StringWidth(ansi.Strip(line))
+}
+
+// centerText centers each line of s in width, measured with textWidth.
 func centerText(s string, width int) string {
 	lines := strings.Split(s, "\n")
 	var centered []string
 	for _, line := range lines {
-		lineWidth := lipgloss.Width(line)
+		lineWidth := textWidth(line)
 		if lineWidth >= width {
 			centered = append(centered, line)
 			continue
@@ -493,6 +870,12 @@ func centerText(s string, width int) string {
 	return strings.Join(centered, "\n")
 }
 
+// centerBlock centers s as a whole, keeping its lines left aligned
+func centerBlock(s string, width int) string {
+	block := lipgloss.NewStyle().Width(lipgloss.Width(s)).Render(s)
+	return centerText(block, width)
+}
+
 func (m model) renderSplash() string {
 	width := m.width
 	height := m.height
@@ -515,15 +898,8 @@ func (m model) renderSplash() string {
 	b.WriteString(centerText(logoStyle.Render(asciiTerminal), width))
 	b.WriteString("\n\n")
 
-	// Typing effect text
-	displayText := splashStyle.Render(m.splashText)
-	cursor := ""
-	if m.showCursor {
-		cursor = cursorStyle.Render("█")
-	} else {
-		cursor = " "
-	}
-	b.WriteString(centerText(displayText+cursor, width))
+	// Scripted typing effect
+	b.WriteString(centerText(m.renderSplashScript(), width))
 
 	// Center vertically
 	content := b.String()
@@ -533,39 +909,44 @@ func (m model) renderSplash() string {
 	return strings.Repeat("\n", topPadding) + content
 }
 
-func (m model) renderMatrix() string {
-	var b strings.Builder
-	for y := 0; y < m.height-2; y++ {
-		for x := 0; x < m.width; x++ {
-			if x < len(m.matrixRain) && y < len(m.matrixRain[x]) && m.matrixRain[x][y] != 0 {
-				intensity := 255 - (y * 10)
-				if intensity < 50 {
-					intensity = 50
-				}
-				color := lipgloss.Color(fmt.Sprintf("#00%02x00", intensity))
-				b.WriteString(lipgloss.NewStyle().Foreground(color).Render(string(m.matrixRain[x][y])))
-			} else {
-				b.WriteString(" ")
-			}
-		}
-		b.WriteString("\n")
-	}
-	hint := centerText(hintStyle.Render("You found the Matrix! Press ESC to return..."), m.width)
-	b.WriteString(hint)
-	return b.String()
+func (m model) View() string {
+	return m.glyphs.apply(m.screen())
 }
 
-func (m model) View() string {
+// screen draws whatever is showing, before the glyph set has its say.
+func (m model) screen() string {
+	if m.farewell {
+		return m.renderFarewell()
+	}
+	if m.saver != nil {
+		return strings.TrimSuffix(m.saver.Render(m.colors), "\n")
+	}
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+
 	// Splash screen
-	if m.view == ViewSplash {
+	if m.page() == pathSplash {
 		return m.renderSplash()
 	}
 
-	// Matrix easter egg
-	if m.view == ViewMatrix {
-		return m.renderMatrix()
+	// Matrix rain and the other effects
+	if m.page() == pathEffects {
+		return m.overPalette(m.renderEffects())
+	}
+
+	// The QR code takes the whole screen
+	if m.page() == pathQR {
+		return m.overPalette(m.renderQR())
 	}
 
+	frame, _ := m.renderBoxed()
+	return m.overPalette(overlay(frame, confettiSprites(m.confetti, m.width, m.glyphs)))
+}
+
+// renderBoxed draws the regular views inside mainBox. It also reports
+// where things landed on screen, e.g. for confetti to burst from.
+func (m model) renderBoxed() (string, layout) {
 	width := m.width
 	height := m.height
 	if width == 0 {
@@ -588,240 +969,201 @@ func (m model) View() string {
 			contentWidth = 72
 		}
 	}
+	if m.twoPane() {
+		// Room for the list and the detail side by side
+		contentWidth = width - 10
+		if contentWidth > twoPaneMaxWidth {
+			contentWidth = twoPaneMaxWidth
+		}
+	}
 
-	if contentWidth < 30 {
+	compact := m.compact()
+	if contentWidth < 30 && !compact {
 		contentWidth = 30 // Absolute minimum to prevents rendering breaks
 	}
 
 	var b strings.Builder
+	var lay layout
 
-	// === RESPONSIVE LOGO ===
-	var logo string
-	if contentWidth >= 60 {
-		// Large logo for bigger terminals
-		logo = `
- ███████╗ █████╗      ██╗     ██╗ █████╗ ██████╗ 
- ██╔════╝██╔══██╗     ██║     ██║██╔══██╗██╔══██╗
- ███████╗███████║     ██║     ██║███████║██║  ██║
- ╚════██║██╔══██║██   ██║██   ██║██╔══██║██║  ██║
- ███████║██║  ██║╚█████╔╝╚█████╔╝██║  ██║██████╔╝
- ╚══════╝╚═╝  ╚═╝ ╚════╝  ╚════╝ ╚═╝  ╚═╝╚═════╝ 
-      █████╗ ██╗██╗   ██╗ ██████╗  ██████╗ ██████╗ 
-     ██╔══██╗██║╚██╗ ██╔╝██╔═══██╗██╔═══██╗██╔══██╗
-     ███████║██║ ╚████╔╝ ██║   ██║██║   ██║██████╔╝
-     ██╔══██║██║  ╚██╔╝  ██║   ██║██║   ██║██╔══██╗
-     ██║  ██║██║   ██║   ╚██████╔╝╚██████╔╝██████╔╝
-     ╚═╝  ╚═╝╚═╝   ╚═╝    ╚═════╝  ╚═════╝ ╚═════╝ 
-`
+	if compact {
+		// === NAME (compact) ===
+		name := ansi.Truncate(logoName, contentWidth, "…")
+		b.WriteString(centerText(logoStyle.Render(name), contentWidth))
+		b.WriteString("\n")
 	} else {
-		// Smaller logo for narrow terminals
-		logo = `
- ╔═╗┌─┐ ┬ ┬┌─┐┌┬┐
- ╚═╗├─┤ │ │├─┤ ││
- ╚═╝┴ ┴└┘└┘┴ ┴─┴┘
-   ╔═╗┬┬ ┬┌─┐┌─┐┌┐ 
-   ╠═╣│└┬┘│ ││ │├┴┐
-   ╩ ╩┴ ┴ └─┘└─┘└─┘
-`
-	}
-	b.WriteString(centerText(logoStyle.Render(logo), contentWidth))
+		// === LOGO ===
+		b.WriteString("\n")
+		b.WriteString(renderLogo(contentWidth, m.fx, m.colors))
+		b.WriteString("\n")
 
-	// === TAGLINE (properly centered) ===
-	taglineText := "Backend Developer · Cloud Enthusiast · DevOps"
-	taglineStyle := lipgloss.NewStyle().Foreground(muted).Italic(true)
-	taglineRendered := taglineStyle.Render(taglineText)
-	b.WriteString("\n")
-	b.WriteString(centerText(taglineRendered, contentWidth))
-	b.WriteString("\n\n")
+		// === TAGLINE (properly centered) ===
+		taglineStyle := lipgloss.NewStyle().Foreground(muted).Italic(true)
+		taglineRendered := taglineStyle.Render(tagline)
+		b.WriteString("\n")
+		b.WriteString(centerText(taglineRendered, contentWidth))
+		b.WriteString("\n\n")
+	}
 
 	// === NAVIGATION ===
-	var navItems []string
-	if m.view == ViewList {
-		navItems = append(navItems, navActive.Render("◆ home"))
-	} else {
-		navItems = append(navItems, navInactive.Render("◇ home"))
-	}
-	navItems = append(navItems, navInactive.Render("│"))
-	if m.view == ViewDetail {
-		navItems = append(navItems, navActive.Render("◆ details"))
-	} else {
-		navItems = append(navItems, navInactive.Render("◇ details"))
+	if !compact {
+		// Breadcrumbs for the current path, the last one where we are
+		crumbs := m.breadcrumbs()
+		var navItems, ids []string
+		for i, c := range crumbs {
+			if i > 0 {
+				navItems = append(navItems, hintStyle.Render("›"))
+				ids = append(ids, "")
+			}
+			switch {
+			case i == len(crumbs)-1:
+				navItems = append(navItems, navActive.Render("◆ "+c.label))
+			case c.path == "":
+				navItems = append(navItems, navInactive.Render(c.label))
+			default:
+				navItems = append(navItems, navInactive.Render("◇ "+c.label))
+			}
+			id := ""
+			if c.path != "" {
+				id = "nav:" + c.path
+			}
+			ids = append(ids, id)
+		}
+		nav := lipgloss.JoinHorizontal(lipgloss.Center, navItems...)
+		// Mark each crumb so it can be clicked
+		col := (contentWidth - lipgloss.Width(nav)) / 2
+		for i, id := range ids {
+			if id != "" {
+				lay.markAt(id, &b, col, navItems[i])
+			}
+			col += lipgloss.Width(navItems[i])
+		}
+		b.WriteString(centerText(nav, contentWidth))
+		b.WriteString("\n")
 	}
-	nav := lipgloss.JoinHorizontal(lipgloss.Center, navItems...)
-	b.WriteString(centerText(nav, contentWidth))
-	b.WriteString("\n")
 
 	// === DIVIDER ===
 	dividerWidth := contentWidth - 10
 	if dividerWidth < 20 {
 		dividerWidth = 20
 	}
+	if compact {
+		dividerWidth = contentWidth - 4
+	}
 	divider := lipgloss.NewStyle().Foreground(dimmed).Render(strings.Repeat("─", dividerWidth))
 	b.WriteString(centerText(divider, contentWidth))
 	b.WriteString("\n")
 
-	if m.view == ViewList {
-		// === PROJECT LIST ===
-		projectSection := sectionStyle.Render("▸ PROJECTS")
-		b.WriteString(centerText(projectSection, contentWidth))
-		b.WriteString("\n\n")
-
-		for i, item := range items {
-			if item.Category == "projects" {
-				icon := item.Icon
-				tag := tagStyle.Render(item.Tag)
-
-				if m.cursor == i {
-					line := fmt.Sprintf("› %s %s  %s", icon, item.Title, tag)
-					b.WriteString(centerText(itemSelected.Render(line), contentWidth))
-				} else {
-					line := fmt.Sprintf("  %s %s  %s", icon, item.Title, tag)
-					b.WriteString(centerText(itemNormal.Render(line), contentWidth))
-				}
-				b.WriteString("\n")
-			}
-		}
-
-		// === ABOUT SECTION ===
-		b.WriteString("\n")
-		aboutSection := sectionStyle.Render("▸ ABOUT")
-		b.WriteString(centerText(aboutSection, contentWidth))
-		b.WriteString("\n\n")
-
-		for i, item := range items {
-			if item.Category == "about" {
-				icon := item.Icon
-				tag := tagStyle.Render(item.Tag)
-
-				if m.cursor == i {
-					line := fmt.Sprintf("› %s %s  %s", icon, item.Title, tag)
-					b.WriteString(centerText(itemSelected.Render(line), contentWidth))
-				} else {
-					line := fmt.Sprintf("  %s %s  %s", icon, item.Title, tag)
-					b.WriteString(centerText(itemNormal.Render(line), contentWidth))
-				}
-				b.WriteString("\n")
-			}
-		}
-
-		// === SOCIAL LINKS (Clickable!) ===
-		b.WriteString("\n")
-		socialSection := sectionStyle.Render("▸ CONNECT")
-		b.WriteString(centerText(socialSection, contentWidth))
-		b.WriteString("\n\n")
-
-		for _, s := range socials {
-			// Show URL directly (OSC8 hyperlinks don't work in all SSH clients)
-			// Calculate padding manually to handle OSC8 sequences correctly
-			visibleText := socialIcon.Render(s.Icon) + socialText.Render(s.URL)
-			textWidth := lipgloss.Width(visibleText)
-			padding := (contentWidth - textWidth) / 2
-			if padding < 0 {
-				padding = 0
-			}
-
-			socialLine := socialIcon.Render(s.Icon) + hyperlink(s.Link, socialText.Render(s.URL))
-			b.WriteString(strings.Repeat(" ", padding) + socialLine)
-			b.WriteString("\n")
-		}
+	// Compact pages keep their hints and toasts in view below the body,
+	// which scrolls
+	bodyTop := strings.Count(b.String(), "\n")
+	var pinned []string
+	pin := func(s string) {
+		pinned = append(pinned, centerText(ansi.Truncate(s, contentWidth, "…"), contentWidth))
+	}
 
-		// === QUOTE (Easter egg) ===
-		if m.showQuote {
-			b.WriteString("\n")
-			quote := quoteStyle.Render(m.currentQuote)
-			b.WriteString(centerText(quote, contentWidth))
+	if m.page() == pathHome {
+		row := strings.Count(b.String(), "\n")
+		if m.twoPane() {
+			// === LIST | LIVE DETAIL ===
+			detailWidth := contentWidth - twoPaneListWidth - twoPaneGap
+			list, listLay := m.renderList(twoPaneListWidth)
+			list = lipgloss.PlaceHorizontal(twoPaneListWidth, lipgloss.Left, strings.TrimSuffix(list, "\n"))
+			card := m.renderDetail(items[m.cursor], detailWidth)
+			detail := centerText("\n"+card, detailWidth)
+			lay.add(listLay, 0, row)
+			var cardLay layout
+			cardLay.regions = []region{{id: "link", w: lipgloss.Width(card), h: lipgloss.Height(card)}}
+			lay.add(cardLay, twoPaneListWidth+twoPaneGap+(detailWidth-lipgloss.Width(card))/2, row+1)
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list, strings.Repeat(" ", twoPaneGap), detail))
 			b.WriteString("\n")
+		} else {
+			list, listLay := m.renderList(contentWidth)
+			lay.add(listLay, 0, row)
+			b.WriteString(list)
 		}
 
-		// === CONFETTI (Easter egg) ===
-		if m.showConfetti {
-			confetti := []string{"🎉", "✨", "🎊", "⭐", "💫", "🌟"}
-			var confettiLine string
-			for i := 0; i < 10; i++ {
-				confettiLine += confetti[rand.Intn(len(confetti))] + " "
-			}
+		// === HINTS ===
+		hints := hintStyle.Render(m.keys.hints(compact,
+			hint{actUp, "navigate"}, hint{actOpen, "view"}, hint{actHelp, "help"}, hint{actQuit, "quit"}))
+		if compact {
+			pin(hints)
+		} else {
 			b.WriteString("\n")
-			b.WriteString(centerText(confettiLine, contentWidth))
+			b.WriteString(centerText(hints, contentWidth))
 		}
 
-		// === HINTS ===
-		b.WriteString("\n")
-		hints := hintStyle.Render("↑↓ navigate · enter view · ? help · q quit")
-		b.WriteString(centerText(hints, contentWidth))
-
-	} else if m.view == ViewDetail {
+	} else if m.page() == routeItem {
 		// === DETAIL VIEW ===
-		item := items[m.cursor]
-
-		// Adjust detail box width based on content width
-		boxWidth := contentWidth - 4
-		if boxWidth < 40 {
-			boxWidth = 40
-		}
-		if boxWidth > 60 {
-			boxWidth = 60
+		b.WriteString("\n")
+		var card string
+		if compact {
+			card = m.renderCompactDetail(items[m.cursor], contentWidth)
+		} else {
+			card = m.renderDetail(items[m.cursor], contentWidth)
 		}
+		lay.mark("link", &b, card, contentWidth)
+		b.WriteString(centerText(card, contentWidth))
+		b.WriteString("\n\n")
 
-		dynamicDetailBox := detailBox.Copy().Width(boxWidth)
-		dynamicDescStyle := descStyle.Copy().Width(boxWidth - 6)
-
-		// Project link
-		var linkLine string
-		if item.Link != "" {
-			linkStyle := lipgloss.NewStyle().Foreground(cyan).Underline(true)
-			visibleLink := linkStyle.Render("→ " + item.Link)
-			linkLine = hyperlink(item.Link, visibleLink)
+		hs := []hint{{actBack, "back"}, {actQuit, "quit"}}
+		if items[m.cursor].Link != "" {
+			hs = append([]hint{{actCopyLink, "copy"}, {actQR, "qr code"}}, hs...)
 		}
-
-		detailContent := lipgloss.JoinVertical(lipgloss.Left,
-			titleStyle.Render(item.Icon+"  "+item.Title),
-			"",
-			techStyle.Render(item.TechStack),
-			"",
-			dynamicDescStyle.Render(item.Description),
-			"",
-			tagStyle.Render(" "+item.Tag+" "),
-		)
-
-		if linkLine != "" {
-			detailContent = lipgloss.JoinVertical(lipgloss.Left, detailContent, "", linkLine)
+		hints := hintStyle.Render(m.keys.hints(compact, hs...))
+		if compact {
+			pin(hints)
+		} else {
+			b.WriteString(centerText(hints, contentWidth))
 		}
-
-		box := dynamicDetailBox.Render(detailContent)
-		b.WriteString("\n")
-		b.WriteString(centerText(box, contentWidth))
-		b.WriteString("\n\n")
-
-		hints := hintStyle.Render("esc back · q quit")
-		b.WriteString(centerText(hints, contentWidth))
-	} else if m.view == ViewHelp {
+	} else if m.page() == pathHelp {
 		// === HELP / SECRETS ===
 		helpSection := sectionStyle.Render("▸ COMMANDS & SECRETS")
 		b.WriteString(centerText(helpSection, contentWidth))
 		b.WriteString("\n\n")
 
-		secrets := []struct{ key, desc string }{
-			{"↑ / k", "Navigate up"},
-			{"↓ / j", "Navigate down"},
-			{"enter / spc", "Open details"},
-			{"esc / bksp", "Go back"},
-			{"q", "Quit"},
-			{"?", "Toggle help"},
-			{"s", "A little surprise"},
-			{"type 'hello'", "Say hello"},
-			{"type 'hire'", "Hiring info"},
-		}
+		b.WriteString(m.renderKeyHelp(contentWidth))
+		b.WriteString("\n")
+		hints := hintStyle.Render(m.keys.hints(false, hint{actBack, "back"}, hint{actQuit, "quit"}))
+		b.WriteString(centerText(hints, contentWidth))
+	} else if m.page() == pathTyping {
+		// === TYPING TEST ===
+		b.WriteString(m.renderTyping(contentWidth))
+	} else if m.page() == pathAchievements {
+		// === ACHIEVEMENTS ===
+		b.WriteString(m.renderAchievements(contentWidth))
+	} else if m.page() == pathGuestbook {
+		// === GUESTBOOK ===
+		b.WriteString(m.renderGuestbook(contentWidth))
+	} else if m.page() == pathShell {
+		// === SHELL ===
+		b.WriteString(m.renderShell(contentWidth))
+	} else if m.page() == pathAsk {
+		// === ASK ME ANYTHING ===
+		b.WriteString(m.renderFAQ(contentWidth))
+	} else if m.page() == routeNotFound {
+		// === NOT FOUND ===
+		b.WriteString(m.renderNotFound(contentWidth))
+	}
 
-		for _, s := range secrets {
-			keyStr := tagStyle.Render(s.key)
-			descStr := itemNormal.Render(s.desc)
-			fullLine := keyStr + "   " + descStr
-			b.WriteString(centerText(fullLine, contentWidth))
-			b.WriteString("\n\n")
-		}
+	// === COPY FALLBACK ===
+	if m.copyFallback != "" {
+		b.WriteString("\n\n")
+		b.WriteString(renderCopyFallback(m.copyFallback, contentWidth))
+	}
 
-		hints := hintStyle.Render("esc back · q quit")
-		b.WriteString(centerText(hints, contentWidth))
+	// === TOAST ===
+	if m.toast != "" && compact {
+		pin(toastStyle.Render(m.toast))
+	} else if m.toast != "" {
+		b.WriteString("\n\n")
+		b.WriteString(centerText(toastStyle.Render(m.toast), contentWidth))
+	}
+
+	if compact {
+		content, fitted := m.fitCompact(b.String(), bodyTop, pinned, contentWidth, height, lay)
+		b.Reset()
+		b.WriteString(content)
+		lay = fitted
 	}
 
 	// === FOOTER ===
@@ -830,17 +1172,29 @@ func (m model) View() string {
 	// footerText := lipgloss.NewStyle().Foreground(dimmed).Render("━━━ © 2026 ━━━")
 
 	// NEW DYNAMIC FOOTER (Uses your hints!):
-	// Pick a hint based on the seconds of the current time so it rotates
-	hintIndex := int(time.Now().Unix() % int64(len(easterEggHints)))
-	hint := easterEggHints[hintIndex]
-	footerText := lipgloss.NewStyle().Foreground(dimmed).Render(fmt.Sprintf("━━━ © 2026 ━━━ %s ━━━", hint))
+	// Hints follow what this visitor hasn't found yet
+	footerText := lipgloss.NewStyle().Foreground(dimmed).Render(fmt.Sprintf("━━━ © 2026 ━━━ %s ━━━", m.hint()))
+	if compact {
+		footerText = lipgloss.NewStyle().Foreground(dimmed).Render("━ © 2026 ━")
+		// Pages that scroll say so; the rest follow the cursor or input
+		if lay.hidden > 0 && m.page() != pathHome && !m.hasInput() && m.copyFallback == "" {
+			more := m.keys.hints(true, hint{actUp, "scroll"}) + " more"
+			footerText = lipgloss.NewStyle().Foreground(dimmed).Render("━ " + more + " ━")
+		}
+	}
 	b.WriteString(centerText(footerText, contentWidth))
 
 	// === MAIN BORDER BOX ===
+	// The width takes in the padding, so compact boxes go without, leaving
+	// content every column of contentWidth
+	padding, sidePadding := 1, 1
+	if compact {
+		padding, sidePadding = 0, 0
+	}
 	mainBox := lipgloss.NewStyle().
 		Border(lipgloss.DoubleBorder()).
-		BorderForeground(accent).
-		Padding(1, 1). // Reduced padding
+		BorderForeground(m.fx.borderColor()).
+		Padding(padding, sidePadding).
 		Width(contentWidth).
 		Render(b.String())
 
@@ -857,10 +1211,207 @@ func (m model) View() string {
 		horizontalPadding = (width - boxWidth) / 2
 	}
 
-	return lipgloss.NewStyle().
+	// Content starts inside the border and padding
+	frame := lipgloss.NewStyle().
 		MarginTop(verticalPadding).
 		MarginLeft(horizontalPadding).
 		Render(mainBox)
+	return frame, lay.offset(horizontalPadding+1+sidePadding, verticalPadding+1+padding)
+}
+
+// renderList draws the home screen sections: projects, about and the
+// social links, centered in width. Regions are relative to its first line.
+func (m model) renderList(width int) (string, layout) {
+	var b strings.Builder
+	var lay layout
+
+	// === PROJECT LIST ===
+	projectSection := sectionStyle.Render("▸ PROJECTS")
+	b.WriteString(centerText(projectSection, width))
+	b.WriteString("\n\n")
+
+	for i, item := range items {
+		if item.Category == "projects" {
+			icon := m.glyphs.icon(item.Icon)
+			tag := tagStyle.Render(item.Tag)
+
+			var rendered string
+			if m.compact() {
+				rendered = m.compactItem(i, width)
+			} else if m.cursor == i {
+				rendered = itemSelected.Render(fmt.Sprintf("› %s %s  %s", icon, item.Title, tag))
+			} else {
+				rendered = itemNormal.Render(fmt.Sprintf("  %s %s  %s", icon, item.Title, tag))
+			}
+			lay.mark(fmt.Sprintf("item:%d", i), &b, rendered, width)
+			b.WriteString(centerText(rendered, width))
+			b.WriteString("\n")
+		}
+	}
+
+	// === ABOUT SECTION ===
+	b.WriteString("\n")
+	aboutSection := sectionStyle.Render("▸ ABOUT")
+	b.WriteString(centerText(aboutSection, width))
+	b.WriteString("\n\n")
+
+	for i, item := range items {
+		if item.Category == "about" {
+			icon := m.glyphs.icon(item.Icon)
+			tag := tagStyle.Render(item.Tag)
+
+			var rendered string
+			if m.compact() {
+				rendered = m.compactItem(i, width)
+			} else if m.cursor == i {
+				rendered = itemSelected.Render(fmt.Sprintf("› %s %s  %s", icon, item.Title, tag))
+			} else {
+				rendered = itemNormal.Render(fmt.Sprintf("  %s %s  %s", icon, item.Title, tag))
+			}
+			lay.mark(fmt.Sprintf("item:%d", i), &b, rendered, width)
+			b.WriteString(centerText(rendered, width))
+			b.WriteString("\n")
+		}
+	}
+
+	// === SOCIAL LINKS (Clickable!) ===
+	b.WriteString("\n")
+	socialSection := sectionStyle.Render("▸ CONNECT")
+	b.WriteString(centerText(socialSection, width))
+	b.WriteString("\n\n")
+
+	for i, s := range socials {
+		// Clickable where the terminal supports it. Otherwise a numbered
+		// list of full URLs, which terminals can usually open themselves.
+		var line string
+		if m.links {
+			url := s.URL
+			if m.compact() {
+				url = ansi.Truncate(url, width-6, "…")
+			}
+			line = socialIcon.Render(m.glyphs.icon(s.Icon)) + m.link(s.Link, socialText.Render(url))
+		} else {
+			url := s.Link
+			if m.compact() {
+				url = ansi.Truncate(url, width-10, "…")
+			}
+			line = footnote(i+1) + " " + socialIcon.Render(m.glyphs.icon(s.Icon)) + socialText.Render(url)
+		}
+		lay.mark(fmt.Sprintf("social:%d", i), &b, line, width)
+		b.WriteString(centerText(line, width))
+		b.WriteString("\n")
+	}
+
+	// === QUOTE (Easter egg) ===
+	if m.showQuote {
+		b.WriteString("\n")
+		quote := quoteStyle.Render(m.currentQuote)
+		b.WriteString(centerText(quote, width))
+		b.WriteString("\n")
+	}
+	return b.String(), lay
+}
+
+// compactItem is item i on one line, without its tag and cut to width.
+func (m model) compactItem(i, width int) string {
+	icon := m.glyphs.icon(items[i].Icon)
+	line := fmt.Sprintf("  %s %s", icon, items[i].Title)
+	style := itemNormal
+	if m.cursor == i {
+		line = fmt.Sprintf("› %s %s", icon, items[i].Title)
+		style = itemSelected
+	}
+	return style.Render(ansi.Truncate(line, width-style.GetHorizontalFrameSize(), "…"))
+}
+
+// renderDetail draws the detail card for item, sized to fit width.
+func (m model) renderDetail(item Item, width int) string {
+	// Adjust detail box width based on content width
+	boxWidth := width - 4
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+	if boxWidth > 60 {
+		boxWidth = 60
+	}
+	if boxWidth > width-6 {
+		boxWidth = width - 6 // Compact screens, room for border and centering
+	}
+
+	dynamicDetailBox := detailBox.Copy().Width(boxWidth)
+	dynamicDescStyle := descStyle.Copy().Width(boxWidth - 6)
+
+	// Project link, clickable or pointing at a footnote under the card
+	var linkLine, note string
+	if item.Link != "" {
+		linkStyle := lipgloss.NewStyle().Foreground(cyan).Underline(true)
+		if m.links {
+			linkLine = m.link(item.Link, linkStyle.Render("→ "+item.Link))
+		} else {
+			// Numbered after the socials when they share the screen
+			n := 1
+			if m.page() == pathHome {
+				n = len(socials) + 1
+			}
+			linkLine = linkStyle.Render("→ "+item.Title+" on the web") + " " + footnote(n)
+			note = renderFootnote(n, item.Link)
+		}
+	}
+
+	detailContent := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(m.glyphs.icon(item.Icon)+"  "+item.Title),
+		"",
+		techStyle.Render(item.TechStack),
+		"",
+		dynamicDescStyle.Render(item.Description),
+		"",
+		tagStyle.Render(" "+item.Tag+" "),
+	)
+
+	if linkLine != "" {
+		detailContent = lipgloss.JoinVertical(lipgloss.Left, detailContent, "", linkLine)
+	}
+	card := dynamicDetailBox.Render(detailContent)
+	if note != "" {
+		// Outside the box, so a long URL never wraps mid way
+		card += "\n" + centerText(note, lipgloss.Width(card))
+	}
+	return card
+}
+
+// renderCompactDetail is the detail for tiny terminals: no card, just the
+// text wrapped to width.
+func (m model) renderCompactDetail(item Item, width int) string {
+	lines := []string{
+		titleStyle.Render(ansi.Truncate(m.glyphs.icon(item.Icon)+" "+item.Title, width, "…")),
+		techStyle.Width(width).Render(item.TechStack),
+		"",
+		descStyle.Width(width).Render(item.Description),
+		"",
+		tagStyle.Render(" " + item.Tag + " "),
+	}
+	if item.Link != "" {
+		linkStyle := lipgloss.NewStyle().Foreground(cyan).Underline(true)
+		if m.links {
+			lines = append(lines, m.link(item.Link, linkStyle.Render(ansi.Truncate("→ "+item.Link, width, "…"))))
+		} else {
+			// In full, to select by hand; the page wraps it
+			lines = append(lines, linkStyle.Render("→ on the web")+" "+footnote(1), renderFootnote(1, item.Link))
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// confettiOrigin is the selected item on the home screen, or the middle
+// of the screen anywhere else.
+func (m model) confettiOrigin() (int, int) {
+	if m.page() == pathHome {
+		_, lay := m.renderBoxed()
+		if r, ok := lay.find(fmt.Sprintf("item:%d", m.cursor)); ok {
+			return r.x + r.w/2, r.y
+		}
+	}
+	return m.width / 2, m.height / 2
 }
 func init() {
 	// Force "True Color" (24-bit) output, bypassing environment checks
@@ -873,13 +1424,34 @@ func init() {
 
 func main() {
 	rand.Seed(time.Now().UnixNano())
+	loadIdleConfig()
+	loadLogoConfig()
+	loadGuestbookConfig()
+	st, err := openStore(storePath)
+	if err != nil {
+		log.Fatalln(err)
+	}
 	s, err := wish.NewServer(
 		wish.WithAddress("0.0.0.0:23234"),
 		wish.WithHostKeyPath(".ssh/term_info_ed25519"),
+		// Accept any key so we can remember visitors by fingerprint, and
+		// let keyless clients in through keyboard-interactive.
+		wish.WithPublicKeyAuth(func(ctx ssh.Context, key ssh.PublicKey) bool {
+			return true
+		}),
+		wish.WithKeyboardInteractiveAuth(func(ctx ssh.Context, challenger gossh.KeyboardInteractiveChallenge) bool {
+			return true
+		}),
 		wish.WithMiddleware(
-			wb.Middleware(func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
-				return initialModel(), []tea.ProgramOption{tea.WithAltScreen()}
-			}),
+			wb.MiddlewareWithProgramHandler(func(s ssh.Session) *tea.Program {
+				m := newSessionModel(s, st)
+				// The program draws through the same writer as the
+				// clipboard, so the two never interleave
+				opts := append(wb.MakeOptions(s), tea.WithOutput(m.out), tea.WithAltScreen(), tea.WithMouseCellMotion())
+				return tea.NewProgram(m, opts...)
+			}, termenv.Ascii),
+			// Runs first: man and fortune never reach the TUI
+			execMiddleware(),
 		),
 	)
 	if err != nil {
@@ -900,7 +1472,12 @@ func main() {
 	log.Println("Stopping server...")
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	if err := s.Shutdown(ctx); err != nil {
-		log.Fatalln(err)
+	shutdownErr := s.Shutdown(ctx)
+	// Whatever the sessions changed last is still only in memory
+	if err := st.Flush(); err != nil {
+		log.Println(err)
+	}
+	if shutdownErr != nil {
+		log.Fatalln(shutdownErr)
 	}
 }
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCenterText(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int // columns the line takes, counted by hand
+	}{
+		{"ascii", "hello", 5},
+		{"cjk", "你好世界", 8},
+		{"emoji", "👋 hi", 5},
+		{"skin tone", "👋🏽", 2},
+		{"flag", "🇯🇵 Tokyo", 8},
+		{"family", "👨‍👩‍👧 home", 7},
+		{"mixed", "日本 👨‍💻 code", 12},
+		{"styled", lipgloss.NewStyle().Bold(true).Render("東京 🗼"), 7},
+	}
+	const target = 30
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textWidth(tt.line); got != tt.width {
+				t.Fatalf("textWidth(%q) = %d, want %d", tt.line, got, tt.width)
+			}
+			centered := centerText(tt.line, target)
+			left := len(centered) - len(strings.TrimLeft(centered, " "))
+			if want := (target - tt.width) / 2; left != want {
+				t.Errorf("centerText(%q) pads %d on the left, want %d", tt.line, left, want)
+			}
+			// Filling out the right side lands exactly on the target
+			right := target - tt.width - left
+			if right < left || right > left+1 {
+				t.Errorf("centerText(%q) leaves %d left and %d right", tt.line, left, right)
+			}
+			if got := textWidth(centered + strings.Repeat(" ", right)); got != target {
+				t.Errorf("centered %q is %d columns wide, want %d", tt.line, got, target)
+			}
+		})
+	}
+}
+
+func TestCenterTextTooWide(t *testing.T) {
+	line := strings.Repeat("漢", 20)
+	if got := centerText(line, 30); got != line {
+		t.Errorf("centerText of a line wider than the width = %q, want it unchanged", got)
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	wb "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- MAN AND FORTUNE ---
+
+// `ssh host man sajjad` prints a manual page for the owner and
+// `ssh host fortune` a quote from a cow. Both also work in the shell.
+
+const (
+	manIndent     = 7
+	manWidth      = 80
+	manShellWidth = 64
+	cowWidth      = 40
+)
+
+// manStyles is how a page sets bold and underlined text. Where it goes
+// decides: a session without a terminal gets plain text.
+type manStyles struct {
+	bold, under lipgloss.Style
+}
+
+// manName is what the owner's page is called, their first name.
+func manName() string {
+	if f := strings.Fields(logoName); len(f) > 0 {
+		return strings.ToLower(f[0])
+	}
+	return "portfolio"
+}
+
+// manPage lays out the owner's page from the content, in the sections
+// man pages have, wrapped to width.
+func manPage(st manStyles, width int) string {
+	name := manName()
+	title := strings.ToUpper(name) + "(1)"
+	var b strings.Builder
+	header := func(left, middle, right string) {
+		gap := max(2, width-len(left)-len(middle)-len(right))
+		b.WriteString(left + strings.Repeat(" ", gap/2) + middle + strings.Repeat(" ", gap-gap/2) + right + "\n")
+	}
+	section := func(s string) {
+		b.WriteString("\n" + st.bold.Render(s) + "\n")
+	}
+	para := func(indent int, text string) {
+		pad := strings.Repeat(" ", indent)
+		for _, line := range strings.Split(ansi.Wordwrap(text, max(20, width-indent), ""), "\n") {
+			b.WriteString(pad + line + "\n")
+		}
+	}
+
+	header(title, "Portfolio Manual", title)
+	section("NAME")
+	para(manIndent, st.bold.Render(name)+" - "+tagline)
+
+	section("SYNOPSIS")
+	para(manIndent, st.bold.Render("ssh")+" [-t] "+st.under.Render("host")+" ["+st.under.Render("page")+"]")
+	para(manIndent, st.bold.Render("ssh")+" "+st.under.Render("host")+" "+st.bold.Render("man "+name)+" | "+st.bold.Render("fortune"))
+
+	about, projects := Item{}, []Item{}
+	for _, item := range items {
+		if item.Category == "projects" {
+			projects = append(projects, item)
+		} else if item.Slug == "about" {
+			about = item
+		}
+	}
+
+	section("DESCRIPTION")
+	para(manIndent, st.bold.Render(logoName)+". "+about.Description)
+
+	section("SKILLS")
+	para(manIndent, strings.Join(skills(), ", ")+".")
+
+	section("PROJECTS")
+	for i, p := range projects {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		para(manIndent, st.bold.Render(p.Title)+"  "+st.under.Render(p.TechStack))
+		para(manIndent+7, p.Description)
+		if p.Link != "" {
+			para(manIndent+7, p.Link)
+		}
+	}
+
+	section("CONTACT")
+	for _, s := range socials {
+		para(manIndent, fmt.Sprintf("%-10s", s.Name)+s.URL)
+	}
+
+	section("SEE ALSO")
+	para(manIndent, st.bold.Render("fortune")+"(6), "+st.bold.Render("ssh")+"(1)")
+
+	b.WriteString("\n")
+	header("portfolio", time.Now().Format("January 2006"), title)
+	return b.String()
+}
+
+// skills is every technology named in the content, once each, in the
+// order they first come up.
+func skills() []string {
+	seen := map[string]bool{}
+	var all []string
+	for _, item := range items {
+		for _, s := range strings.Split(item.TechStack, "·") {
+			s = strings.TrimSpace(s)
+			if s != "" && !seen[strings.ToLower(s)] {
+				seen[strings.ToLower(s)] = true
+				all = append(all, s)
+			}
+		}
+	}
+	return all
+}
+
+// manLookup answers `man args`, the way man does when the page isn't
+// there.
+func manLookup(args []string, st manStyles, width int) (string, bool) {
+	switch {
+	case len(args) == 0:
+		return "What manual page do you want?\nFor example, try 'man " + manName() + "'.\n", false
+	case strings.ToLower(args[len(args)-1]) != manName():
+		return "No manual entry for " + args[len(args)-1] + "\n", false
+	}
+	return manPage(st, width), true
+}
+
+// cowsay puts text in a speech bubble over a cow.
+func cowsay(text string) string {
+	lines := strings.Split(ansi.Wordwrap(text, cowWidth, ""), "\n")
+	w := 0
+	for _, l := range lines {
+		w = max(w, ansi.StringWidth(l))
+	}
+	var b strings.Builder
+	b.WriteString(" " + strings.Repeat("_", w+2) + "\n")
+	for i, l := range lines {
+		left, right := "|", "|"
+		switch {
+		case len(lines) == 1:
+			left, right = "<", ">"
+		case i == 0:
+			left, right = "/", "\\"
+		case i == len(lines)-1:
+			left, right = "\\", "/"
+		}
+		b.WriteString(left + " " + l + strings.Repeat(" ", w-ansi.StringWidth(l)) + " " + right + "\n")
+	}
+	b.WriteString(" " + strings.Repeat("-", w+2) + "\n")
+	b.WriteString(`        \   ^__^
+         \  (oo)\_______
+            (__)\       )\/\
+                ||----w |
+                ||     ||
+`)
+	return b.String()
+}
+
+func fortune() string {
+	return cowsay(quotes[rand.Intn(len(quotes))])
+}
+
+// execMiddleware answers man and fortune straight away, with or without
+// a terminal. Anything else goes on to the TUI, which opens it as a page.
+func execMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			args := s.Command()
+			if len(args) == 0 {
+				next(s)
+				return
+			}
+			r := wb.MakeRenderer(s)
+			st := manStyles{bold: r.NewStyle().Bold(true), under: r.NewStyle().Underline(true)}
+			width := manWidth
+			pty, _, isPty := s.Pty()
+			if isPty && pty.Window.Width > 0 {
+				width = min(manWidth, pty.Window.Width)
+			}
+
+			var out string
+			code := 0
+			switch args[0] {
+			case "man":
+				var ok bool
+				if out, ok = manLookup(args[1:], st, width); !ok {
+					code = 1
+				}
+			case "fortune":
+				out = fortune()
+			default:
+				next(s)
+				return
+			}
+			// Terminals in raw mode don't go back to the start of the line
+			// on their own
+			if isPty {
+				out = strings.ReplaceAll(out, "\n", "\r\n")
+			}
+			if code != 0 {
+				wish.Error(s, out)
+			} else {
+				wish.Print(s, out)
+			}
+			_ = s.Exit(code)
+		}
+	}
+}
+
+// The same, in the shell
+
+func shellMan(m model, args []string) (model, tea.Cmd) {
+	st := manStyles{bold: lipgloss.NewStyle().Foreground(fg).Bold(true), under: lipgloss.NewStyle().Foreground(cyan).Underline(true)}
+	out, ok := manLookup(args, st, manShellWidth)
+	style := shellOut
+	if !ok {
+		style = shellErr
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		m.shell.print(style.Render(line))
+	}
+	return m, nil
+}
+
+func shellFortune(m model, args []string) (model, tea.Cmd) {
+	for _, line := range strings.Split(strings.TrimSuffix(fortune(), "\n"), "\n") {
+		m.shell.print(shellOut.Render(line))
+	}
+	return m, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/muesli/termenv"
+)
+
+// --- MATRIX RAIN ---
+
+// Half-width katakana are one cell wide, so the grid stays aligned
+var matrixGlyphs = []rune("ｱｲｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄﾅﾆﾇﾈﾉ0123456789ABCDEF")
+
+// Trail brightness is quantized so neighbouring cells often share a
+// color and need no new escape sequence. Level 0 is the bright head.
+const matrixLevels = 4
+
+var matrixColors = func() []string {
+	colors := []string{"#D8FFD8"}
+	for i := 1; i < matrixLevels; i++ {
+		colors = append(colors, fmt.Sprintf("#00%02x00", 255-(i-1)*180/(matrixLevels-2)))
+	}
+	return colors
+}()
+
+type matrixColumn struct {
+	head   int // row of the leading glyph, negative while waiting to enter
+	speed  int // ticks per step, lower is faster
+	trail  int
+	glyphs []rune
+}
+
+func newMatrixColumn(height int) matrixColumn {
+	c := matrixColumn{glyphs: make([]rune, height)}
+	c.respawn(height)
+	// Start anywhere so the first frame isn't an empty screen
+	c.head = rand.Intn(height+c.trail) - c.trail
+	return c
+}
+
+func (c *matrixColumn) respawn(height int) {
+	c.speed = 1 + rand.Intn(4)
+	c.trail = 4 + rand.Intn(height/2+1)
+	c.head = -rand.Intn(height + 1)
+}
+
+// matrixRain is the rain grid. It is resized in place, so columns that
+// survive a resize keep falling where they were.
+type matrixRain struct {
+	width, height int
+	tick          int
+	cols          []matrixColumn
+}
+
+func newMatrixRain(width, height int) *matrixRain {
+	r := &matrixRain{}
+	r.Init(width, height)
+	return r
+}
+
+func (r *matrixRain) Name() string { return "matrix" }
+
+func (r *matrixRain) Init(width, height int) {
+	if width < 0 {
+		width = 0
+	}
+	if height < 1 {
+		height = 1
+	}
+	for i := range r.cols {
+		if len(r.cols[i].glyphs) != height {
+			g := make([]rune, height)
+			copy(g, r.cols[i].glyphs)
+			r.cols[i].glyphs = g
+		}
+	}
+	if width < len(r.cols) {
+		r.cols = r.cols[:width]
+	}
+	for len(r.cols) < width {
+		r.cols = append(r.cols, newMatrixColumn(height))
+	}
+	r.width, r.height = width, height
+}
+
+func (r *matrixRain) Step() {
+	r.tick++
+	for i := range r.cols {
+		c := &r.cols[i]
+		// A few glyphs flicker in place, like the film
+		if rand.Intn(20) == 0 {
+			c.glyphs[rand.Intn(r.height)] = matrixGlyphs[rand.Intn(len(matrixGlyphs))]
+		}
+		if r.tick%c.speed != 0 {
+			continue
+		}
+		c.head++
+		if c.head >= 0 && c.head < r.height {
+			c.glyphs[c.head] = matrixGlyphs[rand.Intn(len(matrixGlyphs))]
+		}
+		if c.head-c.trail >= r.height {
+			c.respawn(r.height)
+		}
+	}
+}
+
+// level returns the style index for a cell, or -1 when it is dark.
+func (r *matrixRain) level(x, y int) int {
+	c := &r.cols[x]
+	d := c.head - y
+	if d < 0 || d >= c.trail || c.glyphs[y] == 0 {
+		return -1
+	}
+	if d == 0 {
+		return 0
+	}
+	return 1 + (d-1)*(matrixLevels-1)/c.trail
+}
+
+// Render only emits an escape sequence when the trail level changes,
+// instead of styling every cell on its own.
+func (r *matrixRain) Render(profile termenv.Profile) string {
+	return renderCells(profile, r.width, r.height, matrixColors, func(x, y int) (rune, int) {
+		lvl := r.level(x, y)
+		if lvl < 0 {
+			return ' ', -1
+		}
+		return r.cols[x].glyphs[y], lvl
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// legacyRenderMatrix is the original renderer, kept to compare against:
+// a fresh style and escape sequence for every lit cell.
+func legacyRenderMatrix(grid [][]rune, width, height int) string {
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < len(grid) && y < len(grid[x]) && grid[x][y] != 0 {
+				intensity := 255 - (y * 10)
+				if intensity < 50 {
+					intensity = 50
+				}
+				color := lipgloss.Color(fmt.Sprintf("#00%02x00", intensity))
+				b.WriteString(lipgloss.NewStyle().Foreground(color).Render(string(grid[x][y])))
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// warmRain returns a rain that has been falling for a while, so frames
+// are representative rather than mostly empty.
+func warmRain(width, height int) *matrixRain {
+	r := newMatrixRain(width, height)
+	for i := 0; i < 200; i++ {
+		r.Step()
+	}
+	return r
+}
+
+// litGrid snapshots the cells the new renderer would draw, in the
+// column-major layout the legacy renderer expects.
+func litGrid(r *matrixRain) [][]rune {
+	grid := make([][]rune, r.width)
+	for x := range grid {
+		grid[x] = make([]rune, r.height)
+		for y := range grid[x] {
+			if r.level(x, y) >= 0 {
+				grid[x][y] = r.cols[x].glyphs[y]
+			}
+		}
+	}
+	return grid
+}
+
+func BenchmarkMatrixRenderLegacy(b *testing.B) {
+	r := warmRain(120, 40)
+	grid := litGrid(r)
+	var bytes int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bytes = len(legacyRenderMatrix(grid, r.width, r.height))
+	}
+	b.ReportMetric(float64(bytes), "bytes/frame")
+}
+
+func BenchmarkMatrixRender(b *testing.B) {
+	r := warmRain(120, 40)
+	var bytes int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bytes = len(r.Render(termenv.TrueColor))
+	}
+	b.ReportMetric(float64(bytes), "bytes/frame")
+}
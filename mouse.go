@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- MOUSE ---
+
+// updateMouse handles clicks and the wheel on the boxed views. Clicks are
+// hit-tested against the layout renderBoxed reports, so they follow the
+// same centering and margins as what's on screen.
+func (m model) updateMouse(msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if m.page() == pathHome && m.cursor > 0 {
+			m.cursor--
+			return m.browse()
+		}
+		if m.page() != pathHome {
+			return m.scrollBy(-1), nil
+		}
+	case tea.MouseButtonWheelDown:
+		if m.page() == pathHome && m.cursor < len(items)-1 {
+			m.cursor++
+			return m.browse()
+		}
+		if m.page() != pathHome {
+			return m.scrollBy(1), nil
+		}
+
+	case tea.MouseButtonLeft:
+		_, lay := m.renderBoxed()
+		r, ok := lay.at(msg.X, msg.Y)
+		if !ok {
+			return m, nil
+		}
+		kind, arg, _ := strings.Cut(r.id, ":")
+		switch kind {
+		case "item":
+			i, err := strconv.Atoi(arg)
+			if err != nil || i < 0 || i >= len(items) {
+				return m, nil
+			}
+			m.cursor = i
+			// Side by side, the detail is already showing
+			if m.twoPane() {
+				return m.browse()
+			}
+			m = m.open(i)
+			return m.markViewed(items[m.cursor])
+
+		case "nav":
+			// Breadcrumbs, marked with their path
+			m = m.navigate(arg)
+			if m.page() == routeItem {
+				return m.markViewed(items[m.cursor])
+			}
+
+		case "link":
+			return m.copyLink()
+
+		case "social":
+			i, err := strconv.Atoi(arg)
+			if err != nil || i < 0 || i >= len(socials) {
+				return m, nil
+			}
+			return m.copyToClipboard(socials[i].URL, socials[i].Name)
+		}
+	}
+	return m, nil
+}
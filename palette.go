@@ -0,0 +1,353 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- COMMAND PALETTE ---
+
+// Everything there is to do, by name, for visitors who'd rather type
+// "confetti" than remember c. Opens with : or ctrl+k.
+
+const (
+	paletteQueryLimit = 40
+	paletteShown      = 8
+	paletteWidth      = 52
+	recentLimit       = 5
+	// How much having run a command lately counts against a better match
+	recentBonus = 4
+)
+
+// command is one entry in the palette. when, if set, decides whether it
+// makes sense on the page showing.
+type command struct {
+	id    string
+	title string
+	when  func(m model) bool
+	run   func(m model) (model, tea.Cmd)
+}
+
+type palette struct {
+	open   bool
+	input  lineInput
+	cursor int
+}
+
+func onPage(pages ...string) func(m model) bool {
+	return func(m model) bool {
+		for _, p := range pages {
+			if m.page() == p {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// commands is what the palette offers right now, in a fixed order.
+func (m model) commands() []command {
+	var cmds []command
+	for i, item := range items {
+		i := i
+		cmds = append(cmds, command{
+			id:    "open:" + item.Slug,
+			title: "Open " + item.Title,
+			run: func(m model) (model, tea.Cmd) {
+				m = m.open(i)
+				return m.markViewed(items[i])
+			},
+		})
+	}
+	cmds = append(cmds,
+		command{id: "home", title: "Go home", when: func(m model) bool { return m.page() != pathHome },
+			run: func(m model) (model, tea.Cmd) { return m.navigate(pathHome), nil }},
+		command{id: "back", title: "Go back",
+			run: func(m model) (model, tea.Cmd) { return m.back(), nil }},
+		command{id: "forward", title: "Go forward", when: func(m model) bool { return m.nav.pos < len(m.nav.history)-1 },
+			run: func(m model) (model, tea.Cmd) { return m.forward(), nil }},
+		command{id: "help", title: "Show help and keys",
+			run: func(m model) (model, tea.Cmd) { return m.navigate(pathHelp), nil }},
+		command{id: "achievements", title: "Show achievements",
+			run: func(m model) (model, tea.Cmd) { return m.navigate(pathAchievements), nil }},
+		command{id: "guestbook", title: "Sign the guestbook",
+			run: func(m model) (model, tea.Cmd) { return m.openGuestbook(), nil }},
+		command{id: "typing", title: "Typing speed test",
+			run: func(m model) (model, tea.Cmd) { return m.openPath(pathTyping), nil }},
+		command{id: "ask", title: "Ask me anything",
+			run: func(m model) (model, tea.Cmd) { return m.openFAQ(), nil }},
+		command{id: "shell", title: "Open a shell",
+			run: func(m model) (model, tea.Cmd) { return m.openShell(), nil }},
+		command{id: "effects", title: "Effects gallery",
+			run: func(m model) (model, tea.Cmd) {
+				m = m.openPath(pathEffects)
+				return m, m.tick()
+			}},
+		command{id: "copy-link", title: "Copy " + items[m.cursor].Title + "'s link",
+			when: func(m model) bool {
+				return items[m.cursor].Link != "" && (m.page() == routeItem || (m.page() == pathHome && m.twoPane()))
+			},
+			run: func(m model) (model, tea.Cmd) { return m.copyLink() }},
+		command{id: "qr", title: "Show a link as a QR code", when: onPage(pathHome, routeItem),
+			run: func(m model) (model, tea.Cmd) { return m.openQR(), nil }},
+	)
+	for _, s := range socials {
+		s := s
+		cmds = append(cmds, command{
+			id:    "copy:" + strings.ToLower(s.Name),
+			title: "Copy " + strings.ToLower(s.Name) + " (" + s.URL + ")",
+			run:   func(m model) (model, tea.Cmd) { return m.copyToClipboard(s.URL, s.Name) },
+		})
+	}
+	cmds = append(cmds,
+		command{id: "motion", title: onOff("Turn motion effects", !m.motion),
+			run: func(m model) (model, tea.Cmd) { return m.toggleMotion() }},
+		command{id: "links", title: onOff("Turn clickable links", !m.links),
+			run: func(m model) (model, tea.Cmd) { return m.toggleLinks() }},
+		command{id: "glyphs", title: "Switch theme: " + m.glyphs.name + " glyphs",
+			run: func(m model) (model, tea.Cmd) { return m.cycleGlyphs() }},
+		command{id: "keymap", title: "Switch keys: " + m.keys.name,
+			run: func(m model) (model, tea.Cmd) { return m.cycleKeymap() }},
+	)
+	// Secrets join once they've been found
+	for _, s := range easterEggs {
+		if s.Command == "" || !m.discovered[s.ID] {
+			continue
+		}
+		egg := easterEggRegistry.byID[s.ID]
+		cmds = append(cmds, command{
+			id:    "egg:" + s.ID,
+			title: s.Command,
+			run:   func(m model) (model, tea.Cmd) { return m.fireEgg(egg) },
+		})
+	}
+	cmds = append(cmds, command{id: "quit", title: "Quit",
+		run: func(m model) (model, tea.Cmd) { return m, tea.Quit }})
+
+	shown := cmds[:0]
+	for _, c := range cmds {
+		if c.when == nil || c.when(m) {
+			shown = append(shown, c)
+		}
+	}
+	return shown
+}
+
+func onOff(what string, on bool) string {
+	if on {
+		return what + " on"
+	}
+	return what + " off"
+}
+
+// fuzzyScore reports whether the letters of query appear in text in
+// order, and how well they can: letters in a row and letters starting a
+// word count for more. Spaces in the query are ignored.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	if len(q) == 0 {
+		return 0, true
+	}
+	orig := []rune(text)
+	t := []rune(strings.ToLower(text))
+	// best[j] is the best score for the query so far with its last letter
+	// matched at t[j], or -1 where it can't be
+	best := make([]int, len(t))
+	for qi, r := range q {
+		next := make([]int, len(t))
+		for j := range t {
+			next[j] = -1
+			if t[j] != r {
+				continue
+			}
+			gain := 1
+			if j == 0 || !unicode.IsLetter(orig[j-1]) || (unicode.IsUpper(orig[j]) && unicode.IsLower(orig[j-1])) {
+				gain += 2
+			}
+			if qi == 0 {
+				next[j] = gain
+				continue
+			}
+			for i := 0; i < j; i++ {
+				if best[i] < 0 {
+					continue
+				}
+				score := best[i] + gain
+				if i == j-1 {
+					score += 4
+				}
+				next[j] = max(next[j], score)
+			}
+		}
+		best = next
+	}
+	score := -1
+	for _, s := range best {
+		score = max(score, s)
+	}
+	return score, score >= 0
+}
+
+// matches is the palette's list: every command that fits the query,
+// best first. Recent commands go first when there's no query, and get a
+// head start when there is one.
+func (m model) matches() []command {
+	query := strings.TrimSpace(m.palette.input.String())
+	recency := map[string]int{}
+	for i, id := range m.recent {
+		recency[id] = recentLimit - i
+	}
+	type scored struct {
+		cmd   command
+		score int
+	}
+	var found []scored
+	for _, c := range m.commands() {
+		score, ok := fuzzyScore(query, c.title)
+		if !ok {
+			continue
+		}
+		if r := recency[c.id]; r > 0 {
+			score += recentBonus + r
+		}
+		found = append(found, scored{c, score})
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+	cmds := make([]command, len(found))
+	for i, f := range found {
+		cmds[i] = f.cmd
+	}
+	return cmds
+}
+
+// takesText reports whether the page showing is one visitors type into.
+func (m model) takesText() bool {
+	switch m.page() {
+	case pathTyping, pathGuestbook, pathShell, pathAsk:
+		return true
+	}
+	return false
+}
+
+func (m model) openPalette() model {
+	m.palette = palette{open: true, input: newLineInput(paletteQueryLimit)}
+	return m
+}
+
+// updatePalette types into the query first, so letters never move the
+// cursor, then goes by the keymap. The arrows, tab and enter work in every
+// preset, as they would in any text field.
+func (m model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.palette.input.Update(msg) {
+		m.palette.cursor = 0
+		return m, nil
+	}
+	matches := m.matches()
+	switch a := m.keys.action(key); {
+	case a == actBack || a == actPalette:
+		m.palette.open = false
+	case a == actUp || key == "up" || key == "shift+tab":
+		if m.palette.cursor > 0 {
+			m.palette.cursor--
+		}
+	case a == actDown || a == actNext || key == "down" || key == "tab":
+		if m.palette.cursor < len(matches)-1 {
+			m.palette.cursor++
+		}
+	case a == actOpen || key == "enter":
+		if m.palette.cursor < len(matches) {
+			return m.runCommand(matches[m.palette.cursor])
+		}
+	}
+	return m, nil
+}
+
+// runCommand closes the palette, remembers c as recent and runs it.
+func (m model) runCommand(c command) (model, tea.Cmd) {
+	m.palette.open = false
+	recent := []string{c.id}
+	for _, id := range m.recent {
+		if id != c.id && len(recent) < recentLimit {
+			recent = append(recent, id)
+		}
+	}
+	m.recent = recent
+	return c.run(m)
+}
+
+var paletteBox = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(accent).
+	Padding(0, 1)
+
+func (m model) renderPalette() string {
+	width := paletteWidth
+	if m.width > 0 && m.width-4 < width {
+		width = m.width - 4
+	}
+	inner := width - 4
+
+	var lines []string
+	prompt := socialIcon.Render("› ") + m.palette.input.View(lipgloss.NewStyle().Foreground(fg), inner-2)
+	lines = append(lines, prompt, hintStyle.Render(strings.Repeat("─", inner)))
+
+	matches := m.matches()
+	recent := map[string]bool{}
+	for _, id := range m.recent {
+		recent[id] = true
+	}
+	// Scroll so the cursor stays in view
+	start := 0
+	if m.palette.cursor >= paletteShown {
+		start = m.palette.cursor - paletteShown + 1
+	}
+	for i := start; i < len(matches) && i < start+paletteShown; i++ {
+		c := matches[i]
+		title := ansi.Truncate(c.title, inner-4, "…")
+		mark := "  "
+		if recent[c.id] {
+			mark = hintStyle.Render("↺ ")
+		}
+		if i == m.palette.cursor {
+			lines = append(lines, itemSelected.UnsetPadding().Render("› "+title))
+		} else {
+			lines = append(lines, mark+itemNormal.UnsetPadding().Render(title))
+		}
+	}
+	if len(matches) == 0 {
+		lines = append(lines, hintStyle.Render("No matching commands"))
+	}
+	lines = append(lines, "", hintStyle.Render("↑↓ choose · enter run · esc close"))
+
+	body := lipgloss.NewStyle().Width(inner).Render(strings.Join(lines, "\n"))
+	return paletteBox.Render(body)
+}
+
+// overPalette draws the palette over frame, a third of the way down.
+func (m model) overPalette(frame string) string {
+	if !m.palette.open {
+		return frame
+	}
+	box := strings.Split(m.renderPalette(), "\n")
+	// The frame may be shorter than the screen; the palette still needs
+	// somewhere to go
+	if missing := m.height - strings.Count(frame, "\n") - 1; missing > 0 {
+		frame += strings.Repeat("\n", missing)
+	}
+	x := max(0, (m.width-lipgloss.Width(box[0]))/2)
+	y := max(0, (m.height-len(box))/3)
+	sprites := make([]sprite, len(box))
+	for i, line := range box {
+		sprites[i] = sprite{x: x, y: y + i, s: line}
+	}
+	return overlay(frame, sprites)
+}
@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		score       int
+		ok          bool
+	}{
+		{"", "Anything", 0, true},
+		{"   ", "Anything", 0, true},
+		{"q", "Quit", 3, true},
+		{"quit", "Quit", 3 + 5 + 5 + 5, true},
+		{"QUIT", "quit", 3 + 5 + 5 + 5, true},
+		{"gh", "Go home", 3 + 3, true},
+		{"go home", "Go home", 3 + 5 + 3 + 5 + 5 + 5, true},
+		{"ct", "Typing speed test", 0, false},
+		{"xyz", "Quit", 0, false},
+		{"quitt", "Quit", 0, false},
+		// Letters in a row beat the same letters spread out
+		{"ab", "ab", 3 + 5, true},
+		{"ab", "a_b", 3 + 3, true},
+		{"ab", "axb", 3 + 1, true},
+		// Word starts count, camel case included
+		{"fb", "fooBar", 3 + 3, true},
+	}
+	for _, tt := range tests {
+		score, ok := fuzzyScore(tt.query, tt.text)
+		if ok != tt.ok || (ok && score != tt.score) {
+			t.Errorf("fuzzyScore(%q, %q) = %d, %v, want %d, %v", tt.query, tt.text, score, ok, tt.score, tt.ok)
+		}
+	}
+}
+
+// The best match for a query comes first, whatever the command order.
+func TestFuzzyScoreRanking(t *testing.T) {
+	tests := []struct {
+		query  string
+		better string
+		worse  string
+	}{
+		{"hel", "Show help and keys", "Switch theme: unicode glyphs"},
+		{"conf", "Throw confetti", "Turn motion effects off"},
+		{"home", "Go home", "Copy github (https://github.com/KingSajxxd)"},
+		{"ask", "Ask me anything", "Show achievements"},
+	}
+	for _, tt := range tests {
+		b, okB := fuzzyScore(tt.query, tt.better)
+		w, okW := fuzzyScore(tt.query, tt.worse)
+		if !okB || (okW && w >= b) {
+			t.Errorf("fuzzyScore(%q): %q scores %d, %v, %q scores %d, %v", tt.query, tt.better, b, okB, tt.worse, w, okW)
+		}
+	}
+}
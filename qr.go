@@ -0,0 +1,505 @@
+package main
+
+import "fmt"
+
+// --- QR ENCODER ---
+
+// A small QR code encoder: byte mode only, versions 1 to 10, which holds
+// up to 271 bytes, plenty for a URL. It follows the structure of the
+// spec (ISO/IEC 18004) closely rather than trying to be clever.
+
+type qrLevel int
+
+const (
+	qrL qrLevel = iota // recovers ~7% of the code
+	qrM                // ~15%
+	qrQ                // ~25%
+	qrH                // ~30%
+)
+
+const qrMaxVersion = 10
+
+// Per level and version (index 0 unused): error correction codewords per
+// block, and number of blocks.
+var (
+	qrECCPerBlock = [4][qrMaxVersion + 1]int{
+		{0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18},
+		{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26},
+		{0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24},
+		{0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28},
+	}
+	qrBlocks = [4][qrMaxVersion + 1]int{
+		{0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4},
+		{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5},
+		{0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8},
+		{0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8},
+	}
+	// The two bits each level writes into the format information
+	qrLevelBits = [4]int{1, 0, 3, 2}
+)
+
+// qrCode is a finished symbol; modules[y][x] is true for dark.
+type qrCode struct {
+	version int
+	level   qrLevel
+	size    int
+	modules [][]bool
+}
+
+// qrRawCodewords is how many codewords fit in a version once the function
+// patterns are drawn.
+func qrRawCodewords(ver int) int {
+	bits := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		align := ver/7 + 2
+		bits -= (25*align-10)*align - 55
+		if ver >= 7 {
+			bits -= 36
+		}
+	}
+	return bits / 8
+}
+
+// qrDataCodewords is the room left for data after error correction.
+func qrDataCodewords(ver int, level qrLevel) int {
+	return qrRawCodewords(ver) - qrECCPerBlock[level][ver]*qrBlocks[level][ver]
+}
+
+// qrMinVersion is the smallest version that holds n bytes at level.
+func qrMinVersion(n int, level qrLevel) (int, bool) {
+	for ver := 1; ver <= qrMaxVersion; ver++ {
+		// Mode indicator and character count come first
+		header := 4 + 8
+		if ver >= 10 {
+			header = 4 + 16
+		}
+		if header+8*n <= 8*qrDataCodewords(ver, level) {
+			return ver, true
+		}
+	}
+	return 0, false
+}
+
+// encodeQR builds the smallest symbol for data at level.
+func encodeQR(data []byte, level qrLevel) (*qrCode, error) {
+	ver, ok := qrMinVersion(len(data), level)
+	if !ok {
+		return nil, fmt.Errorf("qr: %d bytes is too long", len(data))
+	}
+
+	// Byte mode segment, terminator and padding
+	var bits qrBits
+	bits.add(0b0100, 4)
+	if ver >= 10 {
+		bits.add(len(data), 16)
+	} else {
+		bits.add(len(data), 8)
+	}
+	for _, b := range data {
+		bits.add(int(b), 8)
+	}
+	capacity := 8 * qrDataCodewords(ver, level)
+	term := capacity - len(bits)
+	if term > 4 {
+		term = 4
+	}
+	bits.add(0, term)
+	bits.add(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.add(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	q := newQRCode(ver, level)
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addECCAndInterleave(codewords))
+	q.chooseMask()
+	return &q.qrCode, nil
+}
+
+type qrBits []bool
+
+func (b *qrBits) add(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, v>>i&1 == 1)
+	}
+}
+
+// qrBuilder tracks which modules belong to function patterns, which masks
+// must leave alone.
+type qrBuilder struct {
+	qrCode
+	function [][]bool
+}
+
+func newQRCode(ver int, level qrLevel) *qrBuilder {
+	size := 17 + 4*ver
+	q := &qrBuilder{qrCode: qrCode{version: ver, level: level, size: size}}
+	q.modules = make([][]bool, size)
+	q.function = make([][]bool, size)
+	for y := range q.modules {
+		q.modules[y] = make([]bool, size)
+		q.function[y] = make([]bool, size)
+	}
+	return q
+}
+
+func (q *qrBuilder) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+func (q *qrBuilder) drawFunctionPatterns() {
+	// Timing patterns, then the finders over their ends
+	for i := 0; i < q.size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	pos := q.alignmentPositions()
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			// Not where the finders are
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			q.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	// Reserve the format areas until a mask is picked
+	q.drawFormat(0)
+	q.drawVersion()
+}
+
+// drawFinder draws a finder and its separator centered on x, y.
+func (q *qrBuilder) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= q.size || yy < 0 || yy >= q.size {
+				continue
+			}
+			d := qrMax(qrAbs(dx), qrAbs(dy))
+			q.set(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (q *qrBuilder) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(x+dx, y+dy, qrMax(qrAbs(dx), qrAbs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPositions lists the row and column centers of the alignment
+// patterns.
+func (q *qrBuilder) alignmentPositions() []int {
+	if q.version == 1 {
+		return nil
+	}
+	n := q.version/7 + 2
+	step := (q.version*4 + n*2 + 1) / (n*2 - 2) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, q.size-7; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// qrFormatBits is the 15 bit format information for level and mask: the
+// five data bits, their BCH code, and the spec's XOR mask over it all.
+func qrFormatBits(level qrLevel, mask int) int {
+	data := qrLevelBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormat writes the level and mask, with their BCH code, in both of
+// the places the spec puts them.
+func (q *qrBuilder) drawFormat(mask int) {
+	bits := qrFormatBits(q.level, mask)
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.set(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(i))
+	}
+	q.set(8, q.size-8, true) // Always dark
+}
+
+// drawVersion writes the version blocks that versions 7 and up carry.
+func (q *qrBuilder) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+	rem := q.version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := q.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.set(a, b, dark)
+		q.set(b, a, dark)
+	}
+}
+
+// addECCAndInterleave splits data into blocks, appends Reed-Solomon error
+// correction to each and interleaves the lot codeword by codeword.
+func (q *qrBuilder) addECCAndInterleave(data []byte) []byte {
+	numBlocks := qrBlocks[q.level][q.version]
+	eccLen := qrECCPerBlock[q.level][q.version]
+	raw := qrRawCodewords(q.version)
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw / numBlocks
+
+	divisor := rsDivisor(eccLen)
+	var blocks [][]byte
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortLen - eccLen
+		if i >= numShort {
+			n++
+		}
+		block := append([]byte{}, data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(block, divisor)
+		// Short blocks get a gap so the columns line up
+		if i < numShort {
+			block = append(block, 0)
+		}
+		blocks = append(blocks, append(block, ecc...))
+	}
+
+	out := make([]byte, 0, raw)
+	for i := 0; i < len(blocks[0]); i++ {
+		for j, block := range blocks {
+			if i != shortLen-eccLen || j >= numShort {
+				out = append(out, block[i])
+			}
+		}
+	}
+	return out
+}
+
+// drawCodewords lays the bits out in the two column wide zigzag, from the
+// bottom right, skipping function modules.
+func (q *qrBuilder) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // The vertical timing pattern
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert // Going up
+				}
+				if !q.function[y][x] && i < len(data)*8 {
+					q.modules[y][x] = data[i/8]>>(7-i%8)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+func qrMaskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (q *qrBuilder) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.function[y][x] && qrMaskBit(mask, x, y) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// chooseMask tries all eight masks and keeps the one the spec's penalty
+// rules like best.
+func (q *qrBuilder) chooseMask() {
+	best, bestScore := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+		if score := q.penalty(); bestScore < 0 || score < bestScore {
+			best, bestScore = mask, score
+		}
+		q.applyMask(mask) // Masks are their own inverse
+	}
+	q.applyMask(best)
+	q.drawFormat(best)
+}
+
+// penalty scores the symbol: long runs, 2x2 blocks, finder lookalikes and
+// an unbalanced dark to light ratio all cost points.
+func (q *qrBuilder) penalty() int {
+	score := 0
+	at := func(x, y int, rows bool) bool {
+		if rows {
+			return q.modules[y][x]
+		}
+		return q.modules[x][y]
+	}
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, rows := range []bool{true, false} {
+		for y := 0; y < q.size; y++ {
+			run := 1
+			for x := 1; x <= q.size; x++ {
+				if x < q.size && at(x, y, rows) == at(x-1, y, rows) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			// 1:1:3:1:1 with four light modules on either side
+			for x := 0; x+7 <= q.size; x++ {
+				match := true
+				for k, dark := range finder {
+					match = match && at(x+k, y, rows) == dark
+				}
+				if !match {
+					continue
+				}
+				light := func(from, to int) bool {
+					for k := from; k < to; k++ {
+						if k >= 0 && k < q.size && at(k, y, rows) {
+							return false
+						}
+					}
+					return true
+				}
+				if light(x-4, x) || light(x+7, x+11) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+	total := q.size * q.size
+	k := (qrAbs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+// Reed-Solomon over GF(2^8) with the QR polynomial x^8+x^4+x^3+x^2+1
+
+func rsMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsDivisor is the generator polynomial of the given degree, leading
+// coefficient left out.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = rsMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = rsMultiply(root, 0x02)
+	}
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= rsMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+func qrAbs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func qrMax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
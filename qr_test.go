@@ -0,0 +1,256 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Byte mode capacities from the spec's tables
+func TestQRMinVersion(t *testing.T) {
+	tests := []struct {
+		level    qrLevel
+		capacity [qrMaxVersion + 1]int
+	}{
+		{qrL, [...]int{0, 17, 32, 53, 78, 106, 134, 154, 192, 230, 271}},
+		{qrM, [...]int{0, 14, 26, 42, 62, 84, 106, 122, 152, 180, 213}},
+		{qrQ, [...]int{0, 11, 20, 32, 46, 60, 74, 86, 108, 130, 151}},
+		{qrH, [...]int{0, 7, 14, 24, 34, 44, 58, 64, 84, 98, 119}},
+	}
+	for _, tt := range tests {
+		t.Run(qrLevelNames[tt.level], func(t *testing.T) {
+			for ver := 1; ver <= qrMaxVersion; ver++ {
+				n := tt.capacity[ver]
+				if got, ok := qrMinVersion(n, tt.level); !ok || got != ver {
+					t.Errorf("qrMinVersion(%d) = %d, %v, want %d", n, got, ok, ver)
+				}
+				next, ok := qrMinVersion(n+1, tt.level)
+				if ver < qrMaxVersion && (!ok || next != ver+1) {
+					t.Errorf("qrMinVersion(%d) = %d, %v, want %d", n+1, next, ok, ver+1)
+				}
+				if ver == qrMaxVersion && ok {
+					t.Errorf("qrMinVersion(%d) = %d, want too long", n+1, next)
+				}
+			}
+		})
+	}
+}
+
+// Format information from the spec's table, level then mask
+func TestQRFormatBits(t *testing.T) {
+	tests := []struct {
+		level qrLevel
+		mask  int
+		want  string
+	}{
+		{qrL, 0, "111011111000100"},
+		{qrL, 4, "110011000101111"},
+		{qrL, 7, "110100101110110"},
+		{qrM, 0, "101010000010010"},
+		{qrM, 5, "100000011001110"},
+		{qrQ, 0, "011010101011111"},
+		{qrQ, 3, "011101000000110"},
+		{qrH, 0, "001011010001001"},
+		{qrH, 6, "000110100001100"},
+		{qrH, 7, "000100000111011"},
+	}
+	for _, tt := range tests {
+		got := qrFormatBits(tt.level, tt.mask)
+		var b strings.Builder
+		for i := 14; i >= 0; i-- {
+			b.WriteByte('0' + byte(got>>i&1))
+		}
+		if b.String() != tt.want {
+			t.Errorf("qrFormatBits(%s, %d) = %s, want %s", qrLevelNames[tt.level], tt.mask, b.String(), tt.want)
+		}
+	}
+}
+
+// readFormat reads both copies of the format information off a symbol.
+func readFormat(code *qrCode) (first, second int) {
+	dark := func(x, y int) int {
+		if code.modules[y][x] {
+			return 1
+		}
+		return 0
+	}
+	for i := 0; i <= 5; i++ {
+		first |= dark(8, i) << i
+	}
+	first |= dark(8, 7)<<6 | dark(8, 8)<<7 | dark(7, 8)<<8
+	for i := 9; i < 15; i++ {
+		first |= dark(14-i, 8) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= dark(code.size-1-i, 8) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= dark(8, code.size-15+i) << i
+	}
+	return first, second
+}
+
+func TestEncodeQR(t *testing.T) {
+	tests := []struct {
+		data    string
+		level   qrLevel
+		version int
+	}{
+		{"https://github.com", qrL, 2},
+		{"https://github.com", qrH, 3},
+		{"hi", qrH, 1},
+		{strings.Repeat("x", 140), qrL, 7},
+		{strings.Repeat("x", 271), qrL, 10},
+	}
+	for _, tt := range tests {
+		code, err := encodeQR([]byte(tt.data), tt.level)
+		if err != nil {
+			t.Fatalf("encodeQR(%d bytes, %s): %v", len(tt.data), qrLevelNames[tt.level], err)
+		}
+		if code.version != tt.version || code.size != 17+4*tt.version {
+			t.Errorf("encodeQR(%d bytes, %s) is v%d, %d wide, want v%d", len(tt.data), qrLevelNames[tt.level], code.version, code.size, tt.version)
+		}
+		first, second := readFormat(code)
+		if first != second {
+			t.Errorf("format copies differ: %015b and %015b", first, second)
+		}
+		found := false
+		for mask := 0; mask < 8; mask++ {
+			found = found || qrFormatBits(tt.level, mask) == first
+		}
+		if !found {
+			t.Errorf("format %015b isn't level %s with any mask", first, qrLevelNames[tt.level])
+		}
+		if !code.modules[code.size-8][8] {
+			t.Error("the module above the bottom left finder should always be dark")
+		}
+	}
+	if _, err := encodeQR(make([]byte, 272), qrL); err == nil {
+		t.Error("encodeQR of 272 bytes should be too long")
+	}
+}
+
+// Version 7 carries its version blocks; 000111110010010100 per the spec.
+func TestEncodeQRVersionBlocks(t *testing.T) {
+	code, err := encodeQR([]byte(strings.Repeat("x", 140)), qrL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = 0x07C94
+	got := 0
+	for i := 0; i < 18; i++ {
+		a, b := code.size-11+i%3, i/3
+		if code.modules[b][a] != code.modules[a][b] {
+			t.Fatalf("version blocks differ at bit %d", i)
+		}
+		if code.modules[b][a] {
+			got |= 1 << i
+		}
+	}
+	if got != want {
+		t.Errorf("version blocks = %018b, want %018b", got, want)
+	}
+}
+
+// fitQR keeps the strongest error correction that fits the screen.
+func TestFitQR(t *testing.T) {
+	const link = "https://github.com/KingSajxxd"
+	tests := []struct {
+		name          string
+		width, height int
+		ascii         bool
+		level         qrLevel
+		ok            bool
+	}{
+		{"roomy", 80, 40, false, qrH, true},
+		{"short", 80, 15, false, qrL, true},
+		{"cramped", 20, 10, false, 0, false},
+		{"ascii roomy", 120, 40, true, qrH, true},
+		{"ascii narrow", 70, 40, true, qrQ, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := fitQR(link, tt.width, tt.height, tt.ascii)
+			if ok != tt.ok {
+				t.Fatalf("fitQR in %dx%d: ok = %v, want %v", tt.width, tt.height, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if code.level != tt.level {
+				t.Errorf("fitQR in %dx%d picked %s, want %s", tt.width, tt.height, qrLevelNames[code.level], qrLevelNames[tt.level])
+			}
+			lines := strings.Split(code.render(tt.ascii), "\n")
+			if len(lines) > tt.height || textWidth(lines[0]) > tt.width {
+				t.Errorf("rendered %dx%d, more than %dx%d", textWidth(lines[0]), len(lines), tt.width, tt.height)
+			}
+		})
+	}
+}
+
+// Both renderings read back as the code, dark on light, with the quiet
+// zone around it.
+func TestQRRender(t *testing.T) {
+	code, err := encodeQR([]byte("https://example.com"), qrM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	side := code.size + 2*qrQuiet
+	want := func(x, y int) bool {
+		x, y = x-qrQuiet, y-qrQuiet
+		return x >= 0 && y >= 0 && x < code.size && y < code.size && code.modules[y][x]
+	}
+
+	// Two rows a line, the top one in the upper half
+	blocks := strings.Split(ansi.Strip(code.render(false)), "\n")
+	if len(blocks) != (side+1)/2 {
+		t.Fatalf("%d half block lines, want %d", len(blocks), (side+1)/2)
+	}
+	for y := 0; y < side; y++ {
+		row := []rune(blocks[y/2])
+		for x := 0; x < side; x++ {
+			c := row[x]
+			got := c == '█' || (y%2 == 0 && c == '▀') || (y%2 == 1 && c == '▄')
+			if got != want(x, y) {
+				t.Fatalf("half blocks: module %d,%d dark = %v", x, y, got)
+			}
+		}
+	}
+
+	// In ASCII, runs of spaces, reversed when dark
+	prefix := func(s lipgloss.Style) string {
+		p, _, _ := strings.Cut(s.Render(" "), " ")
+		return p
+	}
+	light, dark := prefix(qrStyle), prefix(qrStyle.Reverse(true))
+	if light == dark {
+		t.Fatal("dark and light modules look the same")
+	}
+	rows := strings.Split(code.render(true), "\n")
+	if len(rows) != side {
+		t.Fatalf("%d ASCII lines, want %d", len(rows), side)
+	}
+	for y, row := range rows {
+		if strings.Trim(ansi.Strip(row), " ") != "" {
+			t.Fatalf("ASCII line %d draws more than spaces: %q", y, ansi.Strip(row))
+		}
+		x := 0
+		for _, run := range strings.Split(strings.TrimSuffix(row, "\x1b[0m"), "\x1b[0m") {
+			isDark := strings.HasPrefix(run, dark)
+			if !isDark && !strings.HasPrefix(run, light) {
+				t.Fatalf("ASCII line %d: unknown run %q", y, run)
+			}
+			for n := len(ansi.Strip(run)) / 2; n > 0; n-- {
+				if isDark != want(x, y) {
+					t.Fatalf("ASCII: module %d,%d dark = %v", x, y, isDark)
+				}
+				x++
+			}
+		}
+		if x != side {
+			t.Fatalf("ASCII line %d is %d modules, want %d", y, x, side)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- QR VIEW ---
+
+// Light modules around the code. The spec asks for four, but two scans
+// fine off a screen and leaves room for a bigger code.
+const qrQuiet = 2
+
+// Dark modules on a light background, as scanners expect, whatever the
+// terminal's own colors: light modules are the background showing, dark
+// ones are drawn in the foreground color.
+var qrStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#000000")).
+	Background(lipgloss.Color("#FFFFFF"))
+
+// qrTarget is one link the QR view can show.
+type qrTarget struct {
+	title string
+	link  string
+}
+
+// openQR shows the open item's link, when there is one, followed by the
+// socials, with left and right to move between them.
+func (m model) openQR() model {
+	var targets []qrTarget
+	item := items[m.cursor]
+	if item.Link != "" && (m.page() == routeItem || m.twoPane()) {
+		targets = append(targets, qrTarget{item.Title, item.Link})
+	}
+	for _, s := range socials {
+		targets = append(targets, qrTarget{s.Name, s.Link})
+	}
+	m.qrTargets = targets
+	m.qrIndex = 0
+	return m.navigate(pathQR)
+}
+
+func (m model) updateQR(msg tea.KeyMsg) (model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	switch m.keys.action(msg.String()) {
+	case actBack, actQuit, actQR, actPrev:
+		m = m.back()
+	case actForward:
+		m = m.forward()
+	case actLeft:
+		m.qrIndex = (m.qrIndex + len(m.qrTargets) - 1) % len(m.qrTargets)
+	case actRight, actNext:
+		m.qrIndex = (m.qrIndex + 1) % len(m.qrTargets)
+	}
+	return m, nil
+}
+
+// fitQR picks the strongest error correction whose code still fits in
+// width by height cells. Each cell holds two rows of modules, or with
+// ascii half a module, as two cells are needed to make one square.
+func fitQR(link string, width, height int, ascii bool) (*qrCode, bool) {
+	for level := qrH; level >= qrL; level-- {
+		code, err := encodeQR([]byte(link), level)
+		if err != nil {
+			continue
+		}
+		side := code.size + 2*qrQuiet
+		fits := side <= width && (side+1)/2 <= height
+		if ascii {
+			fits = 2*side <= width && side <= height
+		}
+		if fits {
+			return code, true
+		}
+	}
+	return nil, false
+}
+
+// render draws the code with half blocks, two module rows per line. With
+// ascii, for terminals without the blocks, each module is two spaces
+// instead, in reverse video when dark. Both keep the quiet zone.
+func (q *qrCode) render(ascii bool) string {
+	side := q.size + 2*qrQuiet
+	dark := func(x, y int) bool {
+		x, y = x-qrQuiet, y-qrQuiet
+		if x < 0 || y < 0 || x >= q.size || y >= q.size {
+			return false
+		}
+		return q.modules[y][x]
+	}
+
+	var lines []string
+	if ascii {
+		reverse := qrStyle.Reverse(true)
+		for y := 0; y < side; y++ {
+			// One escape per run of modules alike
+			var row strings.Builder
+			for x := 0; x < side; {
+				run := x
+				for run < side && dark(run, y) == dark(x, y) {
+					run++
+				}
+				style := qrStyle
+				if dark(x, y) {
+					style = reverse
+				}
+				row.WriteString(style.Render(strings.Repeat("  ", run-x)))
+				x = run
+			}
+			lines = append(lines, row.String())
+		}
+		return strings.Join(lines, "\n")
+	}
+	for y := 0; y < side; y += 2 {
+		var row strings.Builder
+		for x := 0; x < side; x++ {
+			// Past the bottom edge is outside the code, so light
+			top, bottom := dark(x, y), y+1 < side && dark(x, y+1)
+			switch {
+			case top && bottom:
+				row.WriteRune('█')
+			case top:
+				row.WriteRune('▀')
+			case bottom:
+				row.WriteRune('▄')
+			default:
+				row.WriteRune(' ')
+			}
+		}
+		lines = append(lines, qrStyle.Render(row.String()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var qrLevelNames = [4]string{"L", "M", "Q", "H"}
+
+func (m model) renderQR() string {
+	width, height := m.width, m.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+	target := m.qrTargets[m.qrIndex]
+
+	var names []string
+	for i, t := range m.qrTargets {
+		if i == m.qrIndex {
+			names = append(names, itemSelected.UnsetPadding().Render(t.title))
+		} else {
+			names = append(names, hintStyle.Render(t.title))
+		}
+	}
+	header := strings.Join(names, hintStyle.Render(" · "))
+
+	// Header, link and hints, with a blank line either side of the code
+	code, ok := fitQR(target.link, width-2, height-6, m.glyphs.ascii)
+	var body, hints string
+	if ok {
+		body = code.render(m.glyphs.ascii)
+		hints = fmt.Sprintf("ec %s · v%d   ", qrLevelNames[code.level], code.version)
+	} else {
+		body = socialText.Render("Too small for a QR code here")
+	}
+	hints += m.keys.hints(false, hint{actLeft, "switch"}, hint{actBack, "back"})
+	if m.toast != "" {
+		hints = toastStyle.Render(m.toast)
+	} else {
+		hints = hintStyle.Render(hints)
+	}
+
+	page := lipgloss.JoinVertical(lipgloss.Center,
+		header,
+		"",
+		body,
+		"",
+		socialText.Render(target.link),
+		hints,
+	)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, page)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"strings"
+)
+
+// --- ROUTER ---
+
+// Every screen has a path, like a tiny website: "/" is home,
+// "/projects/pathhelm" a project, "/help" the help page. Where the
+// visitor has been is kept as a history they can go back and forward in.
+const (
+	pathSplash       = "/splash"
+	pathHome         = "/"
+	pathHelp         = "/help"
+	pathTyping       = "/typing"
+	pathAchievements = "/achievements"
+	pathGuestbook    = "/guestbook"
+	pathEffects      = "/effects"
+	pathQR           = "/qr"
+	pathShell        = "/shell"
+	pathAsk          = "/ask"
+)
+
+// Every fixed path. Items have their own paths, see itemPath.
+var routes = map[string]bool{
+	pathSplash:       true,
+	pathHome:         true,
+	pathHelp:         true,
+	pathTyping:       true,
+	pathAchievements: true,
+	pathGuestbook:    true,
+	pathEffects:      true,
+	pathQR:           true,
+	pathShell:        true,
+	pathAsk:          true,
+}
+
+// The pages that aren't a fixed path: any item's, and what's drawn for a
+// path that leads nowhere.
+const (
+	routeItem     = "/:item"
+	routeNotFound = "/:missing"
+)
+
+// Longest history kept; the oldest entries drop off first
+const historyLimit = 64
+
+// router is the visitor's history, with pos the entry on screen.
+type router struct {
+	history []string
+	pos     int
+}
+
+func newRouter(path string) router {
+	return router{history: []string{path}}
+}
+
+func (r router) path() string {
+	return r.history[r.pos]
+}
+
+// push goes to path, dropping anything that was ahead of here.
+func (r *router) push(path string) {
+	if path == r.path() {
+		return
+	}
+	r.history = append(r.history[:r.pos+1:r.pos+1], path)
+	if len(r.history) > historyLimit {
+		r.history = r.history[len(r.history)-historyLimit:]
+	}
+	r.pos = len(r.history) - 1
+}
+
+func (r *router) back() bool {
+	if r.pos == 0 {
+		return false
+	}
+	r.pos--
+	return true
+}
+
+func (r *router) forward() bool {
+	if r.pos == len(r.history)-1 {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+// itemPath is where item i lives: projects under /projects, anything
+// else at the top.
+func itemPath(i int) string {
+	item := items[i]
+	if item.Category == "projects" {
+		return "/projects/" + item.Slug
+	}
+	return "/" + item.Slug
+}
+
+// itemAt is the item whose path is path.
+func itemAt(path string) (int, bool) {
+	for i := range items {
+		if itemPath(i) == path {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// page is the route the current path takes: the path itself for the fixed
+// ones, or routeItem or routeNotFound. Views switch on it to draw the page.
+func (m model) page() string {
+	path := m.nav.path()
+	if routes[path] {
+		// The QR view has nothing to show until it has been opened
+		if path == pathQR && len(m.qrTargets) == 0 {
+			return routeNotFound
+		}
+		return path
+	}
+	if _, ok := itemAt(path); ok {
+		return routeItem
+	}
+	return routeNotFound
+}
+
+// navigate goes to path, adding it to the history.
+func (m model) navigate(path string) model {
+	m.nav.push(path)
+	return m.arrive()
+}
+
+// open shows item i's detail page.
+func (m model) open(i int) model {
+	return m.navigate(itemPath(i))
+}
+
+// back returns to the previous page, or home when there's nothing to go
+// back to.
+func (m model) back() model {
+	if !m.nav.back() {
+		m.nav.push(pathHome)
+	}
+	return m.arrive()
+}
+
+func (m model) forward() model {
+	m.nav.forward()
+	return m.arrive()
+}
+
+// arrive syncs the rest of the model with a new path: item pages move the
+// cursor to their item, and every page starts scrolled to the top.
+func (m model) arrive() model {
+	m.scroll = 0
+	if i, ok := itemAt(m.nav.path()); ok {
+		m.cursor = i
+	}
+	return m
+}
+
+// crumb is one step of the breadcrumb trail in the nav bar.
+type crumb struct {
+	label string
+	path  string // empty when the step isn't a page of its own
+}
+
+// breadcrumbs splits the current path into its steps, starting from home.
+func (m model) breadcrumbs() []crumb {
+	crumbs := []crumb{{label: "home", path: pathHome}}
+	path := m.nav.path()
+	if path == pathHome {
+		return crumbs
+	}
+	prefix := ""
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		prefix += "/" + seg
+		// The path came off the command line, escapes and all
+		c := crumb{label: printable(seg)}
+		if routes[prefix] {
+			c.path = prefix
+		} else if _, ok := itemAt(prefix); ok {
+			c.path = prefix
+		}
+		crumbs = append(crumbs, c)
+	}
+	// Item pages are labelled with the item itself
+	if i, ok := itemAt(path); ok {
+		crumbs[len(crumbs)-1].label = items[i].Title
+	}
+	return crumbs
+}
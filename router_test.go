@@ -0,0 +1,146 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   string // push a path, or < for back and > for forward
+		path    string
+		history []string
+		moved   bool // whether the last back or forward went anywhere
+	}{
+		{"start", "", "/", []string{"/"}, false},
+		{"push", "/help", "/help", []string{"/", "/help"}, false},
+		{"same path twice", "/help /help", "/help", []string{"/", "/help"}, false},
+		{"back", "/help /typing <", "/help", []string{"/", "/help", "/typing"}, true},
+		{"back then forward", "/help /typing < >", "/typing", []string{"/", "/help", "/typing"}, true},
+		{"back past the start", "/help < <", "/", []string{"/", "/help"}, false},
+		{"forward past the end", "/help < > >", "/help", []string{"/", "/help"}, false},
+		{"forward with nowhere to go", ">", "/", []string{"/"}, false},
+		{"push after back drops what was ahead", "/help /typing /ask < < /shell", "/shell", []string{"/", "/help", "/shell"}, true},
+		{"and forward then has nowhere to go", "/help /typing < /shell >", "/shell", []string{"/", "/help", "/shell"}, false},
+		{"pushing where forward leads still drops the rest", "/help /typing /ask < < /typing", "/typing", []string{"/", "/help", "/typing"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRouter("/")
+			moved := false
+			for _, step := range strings.Fields(tt.steps) {
+				switch step {
+				case "<":
+					moved = r.back()
+				case ">":
+					moved = r.forward()
+				default:
+					r.push(step)
+				}
+			}
+			if r.path() != tt.path || !reflect.DeepEqual(r.history, tt.history) {
+				t.Errorf("at %s in %v, want %s in %v", r.path(), r.history, tt.path, tt.history)
+			}
+			if moved != tt.moved {
+				t.Errorf("last move = %v, want %v", moved, tt.moved)
+			}
+		})
+	}
+}
+
+func TestRouterHistoryLimit(t *testing.T) {
+	r := newRouter("/")
+	for i := 0; i < historyLimit+10; i++ {
+		r.push("/" + strings.Repeat("x", i+1))
+	}
+	if len(r.history) != historyLimit || r.pos != historyLimit-1 {
+		t.Fatalf("%d entries at %d, want %d at the end", len(r.history), r.pos, historyLimit)
+	}
+	if r.history[0] == "/" {
+		t.Errorf("the oldest entry wasn't dropped")
+	}
+	for r.back() {
+	}
+	if r.pos != 0 {
+		t.Errorf("back stopped at %d", r.pos)
+	}
+}
+
+// The router doesn't write into a history it shares with a copy of the
+// model from before, as Update hands models around by value.
+func TestRouterCopies(t *testing.T) {
+	before := initialModel().navigate(pathHome).navigate(pathHelp).navigate(pathTyping)
+	before.nav.back()
+	after := before.navigate(pathAsk)
+	before.nav.forward()
+	if before.nav.path() != pathTyping {
+		t.Errorf("the earlier model's forward went to %s", before.nav.path())
+	}
+	if after.nav.path() != pathAsk {
+		t.Errorf("navigate went to %s", after.nav.path())
+	}
+}
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		path string
+		qr   bool
+		want string
+	}{
+		{pathHome, false, pathHome},
+		{pathHelp, false, pathHelp},
+		{"/projects/pathhelm", false, routeItem},
+		{"/about", false, routeItem},
+		{"/projects/nope", false, routeNotFound},
+		{"/projects", false, routeNotFound},
+		{pathQR, false, routeNotFound},
+		{pathQR, true, pathQR},
+		{routeItem, false, routeNotFound},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.nav = newRouter(tt.path)
+		if tt.qr {
+			m.qrTargets = []qrTarget{{"home", "https://example.com"}}
+		}
+		if got := m.page(); got != tt.want {
+			t.Errorf("page at %s = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestBreadcrumbs(t *testing.T) {
+	tests := []struct {
+		path string
+		want []crumb
+	}{
+		{pathHome, []crumb{{"home", pathHome}}},
+		{pathHelp, []crumb{{"home", pathHome}, {"help", pathHelp}}},
+		{"/projects/pathhelm", []crumb{{"home", pathHome}, {"projects", ""}, {"PathHelm", "/projects/pathhelm"}}},
+		{"/nope/deeper", []crumb{{"home", pathHome}, {"nope", ""}, {"deeper", ""}}},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.nav = newRouter(tt.path)
+		if got := m.breadcrumbs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("breadcrumbs at %s = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// back goes home when there's no history, and arriving on an item's page
+// moves the cursor to it.
+func TestModelNavigation(t *testing.T) {
+	m := initialModel()
+	m.nav = newRouter("/projects/pathhelm")
+	m = m.back()
+	if m.nav.path() != pathHome {
+		t.Errorf("back with no history went to %s", m.nav.path())
+	}
+	m = m.open(2).navigate(pathHelp).back()
+	if m.page() != routeItem || items[m.cursor].Slug != items[2].Slug {
+		t.Errorf("back to an item: page %s, cursor on %s", m.page(), items[m.cursor].Slug)
+	}
+}
@@ -0,0 +1,531 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- SHELL ---
+
+// A pretend shell over a pretend filesystem made from the content:
+// projects are markdown files under /projects, the other items text
+// files at the top, and the socials go in /contact.
+
+const (
+	shellHost         = "portfolio"
+	shellInputLimit   = 120
+	shellHistoryLimit = 50
+	shellScrollback   = 200
+	shellRows         = 14
+	shellWidth        = 72
+)
+
+var (
+	shellDir    = lipgloss.NewStyle().Foreground(cyan).Bold(true)
+	shellOut    = lipgloss.NewStyle().Foreground(fgDim)
+	shellErr    = lipgloss.NewStyle().Foreground(red)
+	shellPrompt = lipgloss.NewStyle().Foreground(green).Bold(true)
+)
+
+// vnode is a file or directory in the shell's filesystem. Files made
+// from an item remember which, for open.
+type vnode struct {
+	name     string
+	dir      bool
+	children []*vnode
+	content  string
+	item     int
+}
+
+func (n *vnode) child(name string) *vnode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// display is the name as ls shows it: directories get a slash.
+func (n *vnode) display() string {
+	if n.dir {
+		return shellDir.Render(n.name + "/")
+	}
+	return n.name
+}
+
+var shellFS = newShellFS()
+
+func newShellFS() *vnode {
+	root := &vnode{name: "/", dir: true, item: -1}
+	projects := &vnode{name: "projects", dir: true, item: -1}
+	root.children = append(root.children, projects)
+	for i, item := range items {
+		if item.Category == "projects" {
+			projects.children = append(projects.children, &vnode{name: item.Slug + ".md", content: projectFile(item), item: i})
+		} else {
+			root.children = append(root.children, &vnode{name: item.Slug + ".txt", content: item.Description, item: i})
+		}
+	}
+	var contact []string
+	for _, s := range socials {
+		contact = append(contact, fmt.Sprintf("%-10s %s", s.Name, s.Link))
+	}
+	root.children = append(root.children, &vnode{name: "contact", content: strings.Join(contact, "\n"), item: -1})
+	sortTree(root)
+	return root
+}
+
+func projectFile(item Item) string {
+	lines := []string{"# " + item.Title, "", item.TechStack, "", item.Description}
+	if item.Link != "" {
+		lines = append(lines, "", item.Link)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortTree(n *vnode) {
+	sort.Slice(n.children, func(i, j int) bool { return n.children[i].name < n.children[j].name })
+	for _, c := range n.children {
+		sortTree(c)
+	}
+}
+
+// shell is one session's shell, kept while the visitor is elsewhere so
+// coming back finds it as they left it.
+type shell struct {
+	started bool
+	cwd     string
+	input   lineInput
+	out     []string
+	history []string
+	histPos int
+}
+
+func (sh *shell) print(lines ...string) {
+	for _, l := range lines {
+		sh.out = append(sh.out, strings.Split(l, "\n")...)
+	}
+	if len(sh.out) > shellScrollback {
+		sh.out = sh.out[len(sh.out)-shellScrollback:]
+	}
+}
+
+func (sh *shell) fail(format string, a ...any) {
+	sh.print(shellErr.Render(fmt.Sprintf(format, a...)))
+}
+
+// resolve makes p absolute, from the working directory. ~ is /.
+func (sh *shell) resolve(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		p = "/" + strings.TrimPrefix(strings.TrimPrefix(p, "~"), "/")
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = path.Join(sh.cwd, p)
+	}
+	return path.Clean(p)
+}
+
+// lookup finds the node at p, relative to the working directory.
+func (sh *shell) lookup(p string) *vnode {
+	n := shellFS
+	for _, part := range strings.Split(strings.Trim(sh.resolve(p), "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if !n.dir {
+			return nil
+		}
+		if n = n.child(part); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func (m model) promptText() string {
+	user := m.user
+	if user == "" {
+		user = "guest"
+	}
+	return shellPrompt.Render(user+"@"+shellHost) + shellOut.Render(":") + shellDir.Render(m.shell.cwd) + shellOut.Render("$ ")
+}
+
+// shellCmd is a command the shell knows. run prints through m.shell.
+type shellCmd struct {
+	desc string
+	run  func(m model, args []string) (model, tea.Cmd)
+}
+
+var shellCmds map[string]shellCmd
+
+// Filled in here, since help lists the table it's in
+func init() {
+	shellCmds = map[string]shellCmd{
+		"help":    {"List these commands", shellHelp},
+		"ls":      {"List a directory", shellLs},
+		"cd":      {"Change directory", shellCd},
+		"pwd":     {"Print the working directory", shellPwd},
+		"cat":     {"Print a file", shellCat},
+		"tree":    {"Show everything there is", shellTree},
+		"whoami":  {"Who you are, as far as we know", shellWhoami},
+		"open":    {"Open a project in the portfolio", shellOpen},
+		"clear":   {"Clear the screen", shellClear},
+		"exit":    {"Leave the shell", shellExit},
+		"sudo":    {"Become root", shellSudo},
+		"man":     {"Read a manual page", shellMan},
+		"fortune": {"Hear from a wise cow", shellFortune},
+	}
+}
+
+func shellCmdNames() []string {
+	var names []string
+	for name := range shellCmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func shellHelp(m model, args []string) (model, tea.Cmd) {
+	for _, name := range shellCmdNames() {
+		m.shell.print(shellDir.Render(fmt.Sprintf("%-8s", name)) + shellOut.Render(shellCmds[name].desc))
+	}
+	return m, nil
+}
+
+func shellLs(m model, args []string) (model, tea.Cmd) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	for _, a := range args {
+		n := m.shell.lookup(a)
+		switch {
+		case n == nil:
+			m.shell.fail("ls: cannot access '%s': No such file or directory", a)
+		case !n.dir:
+			m.shell.print(n.display())
+		default:
+			var names []string
+			for _, c := range n.children {
+				names = append(names, c.display())
+			}
+			if len(args) > 1 {
+				m.shell.print(a + ":")
+			}
+			m.shell.print(strings.Join(names, "  "))
+		}
+	}
+	return m, nil
+}
+
+func shellCd(m model, args []string) (model, tea.Cmd) {
+	target := "/"
+	if len(args) > 0 {
+		target = args[0]
+	}
+	n := m.shell.lookup(target)
+	switch {
+	case n == nil:
+		m.shell.fail("cd: %s: No such file or directory", target)
+	case !n.dir:
+		m.shell.fail("cd: %s: Not a directory", target)
+	default:
+		m.shell.cwd = m.shell.resolve(target)
+	}
+	return m, nil
+}
+
+func shellPwd(m model, args []string) (model, tea.Cmd) {
+	m.shell.print(m.shell.cwd)
+	return m, nil
+}
+
+func shellCat(m model, args []string) (model, tea.Cmd) {
+	if len(args) == 0 {
+		m.shell.fail("cat: missing file operand")
+	}
+	for _, a := range args {
+		n := m.shell.lookup(a)
+		switch {
+		case n == nil:
+			m.shell.fail("cat: %s: No such file or directory", a)
+		case n.dir:
+			m.shell.fail("cat: %s: Is a directory", a)
+		default:
+			for _, line := range strings.Split(n.content, "\n") {
+				m.shell.print(shellOut.Render(line))
+			}
+		}
+	}
+	return m, nil
+}
+
+func shellTree(m model, args []string) (model, tea.Cmd) {
+	target := "."
+	if len(args) > 0 {
+		target = args[0]
+	}
+	n := m.shell.lookup(target)
+	if n == nil {
+		m.shell.fail("tree: %s: No such file or directory", target)
+		return m, nil
+	}
+	m.shell.print(shellDir.Render(m.shell.resolve(target)))
+	files, dirs := 0, 0
+	var walk func(n *vnode, indent string)
+	walk = func(n *vnode, indent string) {
+		for i, c := range n.children {
+			branch, next := "├── ", "│   "
+			if i == len(n.children)-1 {
+				branch, next = "└── ", "    "
+			}
+			m.shell.print(shellOut.Render(indent+branch) + c.display())
+			if c.dir {
+				dirs++
+				walk(c, indent+next)
+			} else {
+				files++
+			}
+		}
+	}
+	walk(n, "")
+	m.shell.print("", shellOut.Render(plural(dirs, "directory", "directories")+", "+plural(files, "file", "files")))
+	return m, nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+func shellWhoami(m model, args []string) (model, tea.Cmd) {
+	switch {
+	case m.user == "":
+		m.shell.print("guest")
+	case m.fingerprint == "":
+		m.shell.print(m.user)
+	default:
+		m.shell.print(m.user + shellOut.Render(" ("+m.fingerprint+")"))
+	}
+	return m, nil
+}
+
+// shellOpen takes a project by slug or by file.
+func shellOpen(m model, args []string) (model, tea.Cmd) {
+	if len(args) == 0 {
+		m.shell.fail("usage: open <project>")
+		return m, nil
+	}
+	if n := m.shell.lookup(args[0]); n != nil && !n.dir && n.item >= 0 {
+		m = m.open(n.item)
+		return m.markViewed(items[n.item])
+	}
+	for i, item := range items {
+		if strings.EqualFold(item.Slug, args[0]) {
+			m = m.open(i)
+			return m.markViewed(item)
+		}
+	}
+	m.shell.fail("open: %s: No such project", args[0])
+	return m, nil
+}
+
+func shellClear(m model, args []string) (model, tea.Cmd) {
+	m.shell.out = nil
+	return m, nil
+}
+
+func shellExit(m model, args []string) (model, tea.Cmd) {
+	return m.back(), nil
+}
+
+func shellSudo(m model, args []string) (model, tea.Cmd) {
+	user := m.user
+	if user == "" {
+		user = "guest"
+	}
+	if strings.Join(args, " ") == "rm -rf /" {
+		m.shell.print("Nice try. The portfolio is read-only, and so is your future here.")
+		return m, nil
+	}
+	m.shell.fail("%s is not in the sudoers file. This incident will be reported.", user)
+	return m, nil
+}
+
+func (m model) openShell() model {
+	if !m.shell.started {
+		m.shell = shell{started: true, cwd: "/", input: newLineInput(shellInputLimit)}
+		m.shell.print(shellOut.Render("Welcome! This is a shell over the portfolio. Type 'help' to look around."), "")
+	}
+	return m.navigate(pathShell)
+}
+
+// runLine runs what was typed at the prompt.
+func (m model) runLine() (model, tea.Cmd) {
+	line := strings.TrimSpace(m.shell.input.String())
+	m.shell.input.Reset()
+	m.shell.print(m.promptText() + line)
+	if line == "" {
+		return m, nil
+	}
+	if n := len(m.shell.history); n == 0 || m.shell.history[n-1] != line {
+		m.shell.history = append(m.shell.history, line)
+		if len(m.shell.history) > shellHistoryLimit {
+			m.shell.history = m.shell.history[1:]
+		}
+	}
+	m.shell.histPos = len(m.shell.history)
+
+	fields := strings.Fields(line)
+	cmd, ok := shellCmds[fields[0]]
+	if !ok {
+		m.shell.fail("sh: %s: command not found", fields[0])
+		return m, nil
+	}
+	return cmd.run(m, fields[1:])
+}
+
+// complete finishes the word before the cursor: a command first, a path
+// after that. With several candidates it goes as far as they agree, and
+// lists them. Whatever is after the cursor stays put.
+func (m model) complete() model {
+	line := m.shell.input.String()
+	before := m.shell.input.Before()
+	start := strings.LastIndex(before, " ") + 1
+	word := before[start:]
+	// Don't leave two spaces when the cursor is already before one
+	spaced := strings.HasPrefix(line[len(before):], " ")
+	fill := func(s string) {
+		if spaced {
+			s = strings.TrimSuffix(s, " ")
+		}
+		m.shell.input.SetBefore(before[:start] + s)
+	}
+
+	var candidates []string
+	if strings.TrimSpace(before[:start]) == "" {
+		for _, name := range shellCmdNames() {
+			if strings.HasPrefix(name, word) {
+				candidates = append(candidates, name+" ")
+			}
+		}
+	} else {
+		dir, base := "", word
+		if i := strings.LastIndex(word, "/"); i >= 0 {
+			dir, base = word[:i+1], word[i+1:]
+		}
+		lookIn := dir
+		if lookIn == "" {
+			lookIn = "."
+		}
+		if n := m.shell.lookup(lookIn); n != nil && n.dir {
+			for _, c := range n.children {
+				if !strings.HasPrefix(c.name, base) {
+					continue
+				}
+				if c.dir {
+					candidates = append(candidates, dir+c.name+"/")
+				} else {
+					candidates = append(candidates, dir+c.name+" ")
+				}
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return m
+	case 1:
+		fill(candidates[0])
+		return m
+	}
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) > len(word) {
+		fill(prefix)
+		return m
+	}
+	m.shell.print(m.promptText() + line)
+	var names []string
+	for _, c := range candidates {
+		names = append(names, strings.TrimSpace(c[strings.LastIndex(strings.TrimSuffix(c, "/"), "/")+1:]))
+	}
+	m.shell.print(strings.Join(names, "  "))
+	return m
+}
+
+func (m model) updateShell(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		return m.back(), nil
+	case tea.KeyCtrlD:
+		if m.shell.input.String() == "" {
+			return m.back(), nil
+		}
+	case tea.KeyCtrlL:
+		m.shell.out = nil
+	case tea.KeyEnter:
+		return m.runLine()
+	case tea.KeyTab:
+		return m.complete(), nil
+	case tea.KeyUp:
+		if m.shell.histPos > 0 {
+			m.shell.histPos--
+			m.shell.input.Set(m.shell.history[m.shell.histPos])
+		}
+	case tea.KeyDown:
+		if m.shell.histPos < len(m.shell.history) {
+			m.shell.histPos++
+			m.shell.input.Reset()
+			if m.shell.histPos < len(m.shell.history) {
+				m.shell.input.Set(m.shell.history[m.shell.histPos])
+			}
+		}
+	default:
+		m.shell.input.Update(msg)
+	}
+	return m, nil
+}
+
+func (m model) renderShell(contentWidth int) string {
+	var b strings.Builder
+	b.WriteString(centerText(sectionStyle.Render("▸ SHELL"), contentWidth))
+	b.WriteString("\n\n")
+
+	width := min(shellWidth, contentWidth-4)
+	var lines []string
+	for _, l := range m.shell.out {
+		lines = append(lines, strings.Split(ansi.Wrap(l, width, ""), "\n")...)
+	}
+	prompt := m.promptText()
+	inputWidth := max(8, width-lipgloss.Width(prompt))
+	lines = append(lines, prompt+m.shell.input.View(lipgloss.NewStyle().Foreground(fg), inputWidth))
+	if len(lines) > shellRows {
+		lines = lines[len(lines)-shellRows:]
+	}
+	for len(lines) < shellRows {
+		lines = append(lines, "")
+	}
+	block := lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+	b.WriteString(centerBlock(block, contentWidth))
+	b.WriteString("\n\n")
+
+	hints := hintStyle.Render("tab complete · ↑↓ history · esc back")
+	b.WriteString(centerText(hints, contentWidth))
+	return b.String()
+}
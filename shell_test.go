@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShellResolve(t *testing.T) {
+	tests := []struct {
+		cwd, path string
+		want      string
+	}{
+		{"/", "projects", "/projects"},
+		{"/", "./projects/", "/projects"},
+		{"/projects", "pathhelm.md", "/projects/pathhelm.md"},
+		{"/projects", "..", "/"},
+		{"/projects", "../..", "/"},
+		{"/projects", "../about.txt", "/about.txt"},
+		{"/projects", "/contact", "/contact"},
+		{"/projects", "~", "/"},
+		{"/projects", "~/contact", "/contact"},
+		{"/projects", "~projects", "/projects/~projects"},
+		{"/", "projects//zenroute.md", "/projects/zenroute.md"},
+		{"/", "", "/"},
+	}
+	for _, tt := range tests {
+		sh := shell{cwd: tt.cwd}
+		if got := sh.resolve(tt.path); got != tt.want {
+			t.Errorf("in %s, resolve(%q) = %q, want %q", tt.cwd, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestShellLookup(t *testing.T) {
+	tests := []struct {
+		cwd, path string
+		name      string // empty when nothing is there
+		dir       bool
+	}{
+		{"/", ".", "/", true},
+		{"/", "projects", "projects", true},
+		{"/projects", "zenroute.md", "zenroute.md", false},
+		{"/projects", "../about.txt", "about.txt", false},
+		{"/", "about.txt/anything", "", false},
+		{"/", "projects/missing.md", "", false},
+		{"/", "nowhere", "", false},
+	}
+	for _, tt := range tests {
+		sh := shell{cwd: tt.cwd}
+		n := sh.lookup(tt.path)
+		switch {
+		case tt.name == "" && n != nil:
+			t.Errorf("in %s, lookup(%q) found %s", tt.cwd, tt.path, n.name)
+		case tt.name != "" && n == nil:
+			t.Errorf("in %s, lookup(%q) found nothing, want %s", tt.cwd, tt.path, tt.name)
+		case n != nil && (n.name != tt.name || n.dir != tt.dir):
+			t.Errorf("in %s, lookup(%q) = %s (dir %v), want %s (dir %v)", tt.cwd, tt.path, n.name, n.dir, tt.name, tt.dir)
+		}
+	}
+}
+
+// complete works on the word before the cursor, marked |, and leaves the
+// rest of the line alone.
+func TestShellComplete(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"wh|", "whoami |"},
+		{"  wh|", "  whoami |"},
+		{"c|", "c|"}, // cat, cd and clear
+		{"cat pro|", "cat projects/|"},
+		{"cat projects/pa|", "cat projects/pathhelm.md |"},
+		{"cat projects/|", "cat projects/|"},
+		{"cat c|", "cat contact |"},
+		{"cat x|", "cat x|"},
+		{"ls projects cat pro|", "ls projects cat projects/|"},
+		{"cat a| projects", "cat about.txt| projects"},
+		{"ca| pro", "cat| pro"},
+		{"wh|oami", "whoami |oami"},
+	}
+	for _, tt := range tests {
+		m := initialModel().openShell()
+		m.shell.input.Set(strings.Replace(tt.line, "|", "", 1))
+		m.shell.input.cursor = strings.Index(tt.line, "|")
+		m = m.complete()
+		in := m.shell.input
+		if got := in.Before() + "|" + in.String()[len(in.Before()):]; got != tt.want {
+			t.Errorf("complete(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- SPLASH SCRIPT ---
+
+// A splash script has one step per line:
+//
+//	directive [key=value ...] | text
+//
+// Directives:
+//
+//	type      type the text out, speed=ms per character
+//	say       print the text at once
+//	cmd       a fake shell prompt, then type the text as the command
+//	out       command output, printed at once
+//	progress  a progress bar labelled with the text, filling over speed=ms
+//	pause     wait delay=ms, no text
+//
+// Every step also takes delay=ms to wait before it starts and color=
+// with a palette name (green, accent, cyan, muted, fg, dim) or #RRGGBB.
+// Blank lines and lines starting with # are ignored.
+
+const (
+	splashTypeSpeed     = 45 * time.Millisecond
+	splashProgressSpeed = 800 * time.Millisecond
+	splashBarWidth      = 20
+	splashPrompt        = "visitor@portfolio:~$ "
+)
+
+var splashColors = map[string]lipgloss.Color{
+	"green":  green,
+	"accent": accent,
+	"cyan":   cyan,
+	"muted":  muted,
+	"fg":     fg,
+	"dim":    fgDim,
+}
+
+type splashStep struct {
+	kind  string
+	text  []rune
+	color lipgloss.Color
+	speed time.Duration
+	delay time.Duration
+}
+
+func parseSplashScript(src string) ([]splashStep, error) {
+	var steps []splashStep
+	for n, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		head, text, _ := strings.Cut(line, "|")
+		fields := strings.Fields(head)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("splash line %d: missing directive", n+1)
+		}
+		st := splashStep{kind: fields[0], text: []rune(strings.TrimSpace(text)), color: green}
+		switch st.kind {
+		case "type", "cmd":
+			st.speed = splashTypeSpeed
+		case "progress":
+			st.speed = splashProgressSpeed
+		case "say", "out", "pause":
+		default:
+			return nil, fmt.Errorf("splash line %d: unknown directive %q", n+1, st.kind)
+		}
+		if st.kind == "out" {
+			st.color = fgDim
+		}
+		for _, opt := range fields[1:] {
+			key, val, ok := strings.Cut(opt, "=")
+			if !ok {
+				return nil, fmt.Errorf("splash line %d: option %q is not key=value", n+1, opt)
+			}
+			switch key {
+			case "delay", "speed":
+				ms, err := strconv.Atoi(val)
+				if err != nil || ms < 0 {
+					return nil, fmt.Errorf("splash line %d: %s must be milliseconds", n+1, key)
+				}
+				if key == "delay" {
+					st.delay = time.Duration(ms) * time.Millisecond
+				} else {
+					st.speed = time.Duration(ms) * time.Millisecond
+				}
+			case "color":
+				if c, ok := splashColors[val]; ok {
+					st.color = c
+				} else if strings.HasPrefix(val, "#") && len(val) == 7 {
+					st.color = lipgloss.Color(val)
+				} else {
+					return nil, fmt.Errorf("splash line %d: unknown color %q", n+1, val)
+				}
+			default:
+				return nil, fmt.Errorf("splash line %d: unknown option %q", n+1, key)
+			}
+		}
+		steps = append(steps, st)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("splash script is empty")
+	}
+	return steps, nil
+}
+
+var splashSteps = func() []splashStep {
+	steps, err := parseSplashScript(splashScript)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return steps
+}()
+
+// splashMsg advances the splash by one step of its current line.
+type splashMsg struct{}
+
+func splashAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return splashMsg{}
+	})
+}
+
+// splashPlayer tracks how far the script has played. pos counts runes
+// typed, or bar cells filled for progress lines.
+type splashPlayer struct {
+	line    int
+	pos     int
+	started bool // the current line's delay has passed
+}
+
+func (p splashPlayer) done() bool {
+	return p.line >= len(splashSteps)
+}
+
+// advance plays the script forward one step and returns how long to wait
+// before the next one, or false once the script has finished.
+func (p *splashPlayer) advance() (time.Duration, bool) {
+	if p.done() {
+		return 0, false
+	}
+	st := splashSteps[p.line]
+	if !p.started {
+		p.started = true
+		if st.delay > 0 {
+			return st.delay, true
+		}
+	}
+
+	var wait time.Duration
+	finished := false
+	switch st.kind {
+	case "type", "cmd":
+		p.pos++
+		wait = st.speed
+		finished = p.pos >= len(st.text)
+	case "progress":
+		p.pos++
+		wait = st.speed / splashBarWidth
+		finished = p.pos >= splashBarWidth
+	default:
+		finished = true
+	}
+	if finished {
+		p.line++
+		p.pos = 0
+		p.started = false
+	}
+	return wait, !p.done()
+}
+
+// renderStep draws one script line, showing pos of it.
+func renderStep(st splashStep, pos int) string {
+	style := lipgloss.NewStyle().Foreground(st.color)
+	switch st.kind {
+	case "type":
+		return style.Render(string(st.text[:pos]))
+	case "cmd":
+		return logoStyle.Render(splashPrompt) + style.Render(string(st.text[:pos]))
+	case "progress":
+		bar := style.Render(strings.Repeat("█", pos)) +
+			lipgloss.NewStyle().Foreground(dimmed).Render(strings.Repeat("░", splashBarWidth-pos))
+		pct := fmt.Sprintf("%3d%%", pos*100/splashBarWidth)
+		return style.Render(string(st.text)) + " " + bar + " " + socialText.Render(pct)
+	case "pause":
+		return ""
+	default:
+		return style.Render(string(st.text))
+	}
+}
+
+// stepWidth is how wide a line is once fully played, so the block can
+// be laid out before it has finished typing.
+func stepWidth(st splashStep) int {
+	switch st.kind {
+	case "cmd":
+		return lipgloss.Width(splashPrompt + string(st.text))
+	case "progress":
+		return lipgloss.Width(string(st.text)) + splashBarWidth + 6
+	default:
+		return lipgloss.Width(string(st.text))
+	}
+}
+
+// renderSplashScript draws everything played so far plus the cursor.
+func (m model) renderSplashScript() string {
+	var lines []string
+	blockWidth := 0
+	for i, st := range splashSteps {
+		if w := stepWidth(st); w+1 > blockWidth {
+			blockWidth = w + 1
+		}
+		if i > m.splash.line || st.kind == "pause" {
+			continue
+		}
+		pos := len(st.text)
+		if st.kind == "progress" {
+			pos = splashBarWidth
+		}
+		if i == m.splash.line {
+			// Instant lines only show up once their delay is over
+			if !m.splash.started || st.kind == "say" || st.kind == "out" {
+				continue
+			}
+			pos = m.splash.pos
+		}
+		lines = append(lines, renderStep(st, pos))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "")
+	}
+
+	cursor := " "
+	if m.showCursor {
+		cursor = cursorStyle.Render("█")
+	}
+	lines[len(lines)-1] += cursor
+	// A fixed size block keeps the splash from shifting as it plays
+	height := 0
+	for _, st := range splashSteps {
+		if st.kind != "pause" {
+			height++
+		}
+	}
+	return lipgloss.NewStyle().Width(blockWidth).Height(height).Render(strings.Join(lines, "\n"))
+}
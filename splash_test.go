@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseSplashScript(t *testing.T) {
+	src := `
+# a comment, then a blank line
+
+type | Hello
+type speed=10 delay=200 color=accent | Fast
+cmd | whoami
+out | visitor
+say color=#123456 | Ready
+progress speed=0 | Loading
+pause delay=500
+`
+	steps, err := parseSplashScript(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []splashStep{
+		{kind: "type", text: []rune("Hello"), color: green, speed: splashTypeSpeed},
+		{kind: "type", text: []rune("Fast"), color: accent, speed: 10 * time.Millisecond, delay: 200 * time.Millisecond},
+		{kind: "cmd", text: []rune("whoami"), color: green, speed: splashTypeSpeed},
+		{kind: "out", text: []rune("visitor"), color: fgDim},
+		{kind: "say", text: []rune("Ready"), color: lipgloss.Color("#123456")},
+		{kind: "progress", text: []rune("Loading"), color: green},
+		{kind: "pause", text: []rune{}, color: green, delay: 500 * time.Millisecond},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("%d steps, want %d", len(steps), len(want))
+	}
+	for i, got := range steps {
+		w := want[i]
+		if got.kind != w.kind || string(got.text) != string(w.text) || got.color != w.color || got.speed != w.speed || got.delay != w.delay {
+			t.Errorf("step %d = %+v, want %+v", i+1, got, w)
+		}
+	}
+}
+
+func TestParseSplashScriptErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"empty", "", "splash script is empty"},
+		{"only comments", "# nothing\n\n# here", "splash script is empty"},
+		{"no directive", "say | ok\n| text", "splash line 2: missing directive"},
+		{"unknown directive", "shout | hi", `splash line 1: unknown directive "shout"`},
+		{"not key=value", "type fast | hi", `option "fast" is not key=value`},
+		{"unknown option", "type volume=11 | hi", `unknown option "volume"`},
+		{"bad duration", "pause delay=1s", "delay must be milliseconds"},
+		{"negative duration", "type speed=-5 | hi", "speed must be milliseconds"},
+		{"unknown color", "say color=mauve | hi", `unknown color "mauve"`},
+		{"short hex color", "say color=#fff | hi", `unknown color "#fff"`},
+	}
+	for _, tt := range tests {
+		_, err := parseSplashScript(tt.src)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: parseSplashScript: %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+// The script the server ships with parses.
+func TestSplashScript(t *testing.T) {
+	if _, err := parseSplashScript(splashScript); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// --- PERSISTENCE ---
+
+// Where per-visitor data lives, relative to the working directory
+// (next to the .ssh host key).
+const storePath = ".data/visitors.json"
+
+const (
+	// Only the newest guestbook entries are kept
+	guestbookLimit = 100
+	// How long a key waits between guestbook entries
+	guestbookCooldown = 10 * time.Minute
+	// Past this many keys, the one seen longest ago is forgotten
+	visitorLimit = 10000
+	// Changes are written out together, this long after the first one
+	storeFlushDelay = 2 * time.Second
+)
+
+// visitorRecord is everything we remember about one public key.
+type visitorRecord struct {
+	TypingBest   typingResult         `json:"typing_best"`
+	Achievements map[string]time.Time `json:"achievements,omitempty"`
+	Viewed       map[string]bool      `json:"viewed,omitempty"` // by slug
+	Seen         time.Time            `json:"seen"`             // last change
+	Signed       time.Time            `json:"signed,omitempty"` // last guestbook entry
+}
+
+// clone copies the maps too, so callers never share them with the store.
+func (r visitorRecord) clone() visitorRecord {
+	c := r
+	c.Achievements = make(map[string]time.Time, len(r.Achievements))
+	for k, v := range r.Achievements {
+		c.Achievements[k] = v
+	}
+	c.Viewed = make(map[string]bool, len(r.Viewed))
+	for k, v := range r.Viewed {
+		c.Viewed[k] = v
+	}
+	return c
+}
+
+type guestbookEntry struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Key     string    `json:"key,omitempty"` // fingerprint of whoever signed
+	Message string    `json:"message"`
+	When    time.Time `json:"when"`
+	Hidden  bool      `json:"hidden,omitempty"` // by a moderator
+}
+
+type storeData struct {
+	Visitors  map[string]*visitorRecord `json:"visitors"`
+	Guestbook []guestbookEntry          `json:"guestbook"`
+	LastEntry int                       `json:"last_entry"`
+}
+
+var (
+	errSignAnonymous = errors.New("connect with an SSH key to sign")
+	errSignTooSoon   = fmt.Errorf("you can sign once every %d minutes", int(guestbookCooldown/time.Minute))
+)
+
+// visitorStore is a tiny JSON file with per-key records and the shared
+// guestbook. Every session uses it, so all access goes through the mutex.
+// Sessions only change the copy in memory; a timer writes it out shortly
+// after, so nobody waits on the disk.
+type visitorStore struct {
+	mu      sync.Mutex
+	path    string
+	data    storeData
+	limit   int
+	dirty   bool
+	flusher *time.Timer
+	// Held while writing, so snapshots reach the disk in order
+	writing sync.Mutex
+}
+
+func openStore(path string) (*visitorStore, error) {
+	st := &visitorStore{path: path, limit: visitorLimit}
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &st.data); err != nil {
+			return nil, err
+		}
+	}
+	if st.data.Visitors == nil {
+		st.data.Visitors = map[string]*visitorRecord{}
+	}
+	// Entries from before IDs existed get one, so they can be hidden
+	for i := range st.data.Guestbook {
+		if st.data.Guestbook[i].ID == 0 {
+			st.data.LastEntry++
+			st.data.Guestbook[i].ID = st.data.LastEntry
+		}
+	}
+	return st, nil
+}
+
+// Get returns a copy of the record for fp. Anonymous visitors (empty
+// fingerprint) and a nil store always get an empty record.
+func (st *visitorStore) Get(fp string) visitorRecord {
+	if st == nil || fp == "" {
+		return visitorRecord{}.clone()
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if r, ok := st.data.Visitors[fp]; ok {
+		return r.clone()
+	}
+	return visitorRecord{}.clone()
+}
+
+// Update applies fn to the record for fp. The file catches up later.
+func (st *visitorStore) Update(fp string, fn func(r *visitorRecord)) {
+	if st == nil || fp == "" {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	fn(st.record(fp))
+	st.changed()
+}
+
+// record finds or makes the record for fp, making room for a new one by
+// forgetting whoever was seen longest ago. The caller holds the mutex.
+func (st *visitorStore) record(fp string) *visitorRecord {
+	r, ok := st.data.Visitors[fp]
+	if !ok {
+		for len(st.data.Visitors) >= st.limit {
+			stalest := ""
+			for k, v := range st.data.Visitors {
+				if stalest == "" || v.Seen.Before(st.data.Visitors[stalest].Seen) {
+					stalest = k
+				}
+			}
+			delete(st.data.Visitors, stalest)
+		}
+		r = &visitorRecord{}
+		st.data.Visitors[fp] = r
+	}
+	r.Seen = time.Now()
+	return r
+}
+
+// Guestbook returns the entries nobody has hidden, newest last.
+func (st *visitorStore) Guestbook() []guestbookEntry {
+	if st == nil {
+		return nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var shown []guestbookEntry
+	for _, e := range st.data.Guestbook {
+		if !e.Hidden {
+			shown = append(shown, e)
+		}
+	}
+	return shown
+}
+
+// Sign adds e to the guestbook, numbering it. Each key signs at most
+// once per guestbookCooldown, and keyless visitors not at all, so one
+// visitor can't flood the book.
+func (st *visitorStore) Sign(e guestbookEntry) (guestbookEntry, error) {
+	if e.Key == "" {
+		return e, errSignAnonymous
+	}
+	if st == nil {
+		return e, nil
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	r := st.data.Visitors[e.Key]
+	if r != nil && e.When.Sub(r.Signed) < guestbookCooldown {
+		return e, errSignTooSoon
+	}
+	st.record(e.Key).Signed = e.When
+	st.data.LastEntry++
+	e.ID = st.data.LastEntry
+	st.data.Guestbook = append(st.data.Guestbook, e)
+	if n := len(st.data.Guestbook); n > guestbookLimit {
+		st.data.Guestbook = st.data.Guestbook[n-guestbookLimit:]
+	}
+	st.changed()
+	return e, nil
+}
+
+// Hide takes entry id out of the guestbook, reporting whether it was
+// there.
+func (st *visitorStore) Hide(id int) bool {
+	if st == nil {
+		return false
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for i := range st.data.Guestbook {
+		if e := &st.data.Guestbook[i]; e.ID == id && !e.Hidden {
+			e.Hidden = true
+			st.changed()
+			return true
+		}
+	}
+	return false
+}
+
+// changed schedules a write, unless one is already on its way. The
+// caller holds the mutex.
+func (st *visitorStore) changed() {
+	st.dirty = true
+	if st.flusher == nil {
+		st.flusher = time.AfterFunc(storeFlushDelay, func() {
+			if err := st.Flush(); err != nil {
+				log.Printf("saving visitors: %v", err)
+			}
+		})
+	}
+}
+
+// Flush writes out any changes now. Call it once more on the way out.
+func (st *visitorStore) Flush() error {
+	if st == nil {
+		return nil
+	}
+	st.writing.Lock()
+	defer st.writing.Unlock()
+	st.mu.Lock()
+	if st.flusher != nil {
+		st.flusher.Stop()
+		st.flusher = nil
+	}
+	if !st.dirty {
+		st.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(st.data, "", "  ")
+	st.dirty = err != nil
+	st.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := st.save(data); err != nil {
+		// Keep the changes for the next try
+		st.mu.Lock()
+		st.dirty = true
+		st.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (st *visitorStore) save(data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o700); err != nil {
+		return err
+	}
+	// Write to a temp file first so a crash never leaves half a file behind
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
+
+// fingerprint identifies a visitor by their public key, or "" when they
+// connected without one.
+func fingerprint(s ssh.Session) string {
+	if s.PublicKey() == nil {
+		return ""
+	}
+	return gossh.FingerprintSHA256(s.PublicKey())
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visitors.json")
+	st, err := openStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Update("SHA256:a", func(r *visitorRecord) { r.Viewed = map[string]bool{"pathhelm": true} })
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Update wrote the file straight away")
+	}
+	if err := st.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := openStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := again.Get("SHA256:a"); !r.Viewed["pathhelm"] {
+		t.Errorf("reopened record = %+v, want pathhelm viewed", r)
+	}
+	// Nothing changed, so nothing to write
+	os.Remove(path)
+	if err := st.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Flush wrote without changes")
+	}
+}
+
+func TestStoreFlushesOnItsOwn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visitors.json")
+	st, err := openStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Update("SHA256:a", func(r *visitorRecord) {})
+	deadline := time.Now().Add(storeFlushDelay + 2*time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Errorf("no file %v after the change", storeFlushDelay)
+}
+
+func TestStoreForgetsStalest(t *testing.T) {
+	st, err := openStore(filepath.Join(t.TempDir(), "visitors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Flush()
+	st.limit = 3
+	for i := 0; i < 5; i++ {
+		st.Update(fmt.Sprintf("key%d", i), func(r *visitorRecord) {})
+		time.Sleep(time.Millisecond)
+	}
+	// Seen again, so no longer the stalest
+	st.Update("key2", func(r *visitorRecord) {})
+	st.Update("key5", func(r *visitorRecord) {})
+
+	if n := len(st.data.Visitors); n != 3 {
+		t.Errorf("%d records, want 3", n)
+	}
+	for key, kept := range map[string]bool{"key0": false, "key1": false, "key2": true, "key3": false, "key4": true, "key5": true} {
+		if _, ok := st.data.Visitors[key]; ok != kept {
+			t.Errorf("%s kept = %v, want %v", key, ok, kept)
+		}
+	}
+}
+
+func TestStoreAnonymous(t *testing.T) {
+	st, err := openStore(filepath.Join(t.TempDir(), "visitors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Update("", func(r *visitorRecord) { r.TypingBest.WPM = 99 })
+	if len(st.data.Visitors) != 0 || st.dirty {
+		t.Errorf("keyless visitor was remembered")
+	}
+	var none *visitorStore
+	none.Update("SHA256:a", func(r *visitorRecord) {})
+	if err := none.Flush(); err != nil {
+		t.Errorf("nil store Flush: %v", err)
+	}
+}
+
+func TestStoreSign(t *testing.T) {
+	st, err := openStore(filepath.Join(t.TempDir(), "visitors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Flush()
+	start := time.Now()
+	tests := []struct {
+		name  string
+		key   string
+		after time.Duration
+		err   error
+	}{
+		{"first entry", "SHA256:a", 0, nil},
+		{"again straight away", "SHA256:a", time.Minute, errSignTooSoon},
+		{"someone else", "SHA256:b", time.Minute, nil},
+		{"after the cooldown", "SHA256:a", guestbookCooldown, nil},
+		{"no key", "", guestbookCooldown, errSignAnonymous},
+	}
+	for _, tt := range tests {
+		e := guestbookEntry{Key: tt.key, Message: tt.name, When: start.Add(tt.after)}
+		if _, err := st.Sign(e); err != tt.err {
+			t.Errorf("%s: Sign = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+	book := st.Guestbook()
+	var ids []int
+	for _, e := range book {
+		ids = append(ids, e.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("IDs = %v, want [1 2 3]", ids)
+	}
+
+	if !st.Hide(2) {
+		t.Errorf("Hide(2) = false")
+	}
+	if st.Hide(2) || st.Hide(99) {
+		t.Errorf("hid an entry that wasn't shown")
+	}
+	for _, e := range st.Guestbook() {
+		if e.ID == 2 {
+			t.Errorf("hidden entry still in the guestbook")
+		}
+	}
+}
+
+// Entries written before they had IDs get numbered when loaded.
+func TestOpenStoreNumbersEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visitors.json")
+	old := `{"visitors": {}, "guestbook": [{"name": "a", "message": "hi"}, {"name": "b", "message": "yo"}]}`
+	if err := os.WriteFile(path, []byte(old), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	st, err := openStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Sign(guestbookEntry{Key: "SHA256:c", Message: "new", When: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	defer st.Flush()
+	for i, e := range st.Guestbook() {
+		if e.ID != i+1 {
+			t.Errorf("entry %d has ID %d", i, e.ID)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- TYPING TEST ---
+
+var (
+	red = lipgloss.Color("#FF4D6D")
+
+	// Indexed by the typedState* constants
+	typedStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(green),
+		lipgloss.NewStyle().Foreground(fg).Background(red),
+		lipgloss.NewStyle().Foreground(fg).Underline(true),
+		lipgloss.NewStyle().Foreground(subtle),
+	}
+)
+
+const (
+	typedStateCorrect = iota
+	typedStateWrong
+	typedStateCursor
+	typedStatePending
+)
+
+// typingResult is one finished run. It is what gets persisted as a
+// visitor's personal best.
+type typingResult struct {
+	WPM      float64   `json:"wpm"`
+	Accuracy float64   `json:"accuracy"`
+	Quote    string    `json:"quote"`
+	When     time.Time `json:"when"`
+}
+
+// better reports whether r beats best: faster wins, accuracy breaks ties.
+func (r typingResult) better(best typingResult) bool {
+	if r.WPM != best.WPM {
+		return r.WPM > best.WPM
+	}
+	return r.Accuracy > best.Accuracy
+}
+
+type typingTest struct {
+	target     []rune
+	typed      []rune
+	keystrokes int
+	mistakes   int
+	started    time.Time
+	finished   time.Time
+	done       bool
+	newBest    bool
+}
+
+// quoteText strips the surrounding quote marks and attribution so only
+// the saying itself has to be typed.
+func quoteText(q string) string {
+	first := strings.Index(q, "\"")
+	last := strings.LastIndex(q, "\"")
+	if first == -1 || last <= first {
+		return q
+	}
+	return q[first+1 : last]
+}
+
+func newTypingTest() typingTest {
+	q := quoteText(quotes[rand.Intn(len(quotes))])
+	return typingTest{target: []rune(q)}
+}
+
+// input feeds typed runes into the test. Everything is rune based so
+// multi-byte characters and keys that arrive in one burst count properly.
+func (t *typingTest) input(runes []rune) {
+	for _, r := range runes {
+		if t.done {
+			return
+		}
+		if t.started.IsZero() {
+			t.started = time.Now()
+		}
+		if r != t.target[len(t.typed)] {
+			t.mistakes++
+		}
+		t.keystrokes++
+		t.typed = append(t.typed, r)
+		if len(t.typed) == len(t.target) {
+			t.done = true
+			t.finished = time.Now()
+		}
+	}
+}
+
+func (t *typingTest) backspace() {
+	if !t.done && len(t.typed) > 0 {
+		t.typed = t.typed[:len(t.typed)-1]
+	}
+}
+
+func (t typingTest) elapsed() time.Duration {
+	if t.started.IsZero() {
+		return 0
+	}
+	if t.done {
+		return t.finished.Sub(t.started)
+	}
+	return time.Since(t.started)
+}
+
+func (t typingTest) correct() int {
+	n := 0
+	for i, r := range t.typed {
+		if r == t.target[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// wpm uses the usual five-characters-per-word convention and only
+// counts characters that are currently correct.
+func (t typingTest) wpm() float64 {
+	minutes := t.elapsed().Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(t.correct()) / 5 / minutes
+}
+
+func (t typingTest) accuracy() float64 {
+	if t.keystrokes == 0 {
+		return 100
+	}
+	return float64(t.keystrokes-t.mistakes) / float64(t.keystrokes) * 100
+}
+
+func (t typingTest) result() typingResult {
+	return typingResult{
+		WPM:      t.wpm(),
+		Accuracy: t.accuracy(),
+		Quote:    string(t.target),
+		When:     t.finished,
+	}
+}
+
+func (m model) updateTyping(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	wasDone := m.typing.done
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m = m.back()
+	case tea.KeyTab:
+		m.typing = newTypingTest()
+	case tea.KeyEnter:
+		if m.typing.done {
+			m.typing = newTypingTest()
+		}
+	case tea.KeyBackspace:
+		m.typing.backspace()
+	case tea.KeySpace:
+		m.typing.input([]rune{' '})
+	case tea.KeyRunes:
+		// Pasting the quote is cheating
+		if msg.Paste {
+			return m, nil
+		}
+		m.typing.input(msg.Runes)
+	}
+
+	if m.typing.done && !wasDone {
+		res := m.typing.result()
+		if res.better(m.typingBest) {
+			m.typingBest = res
+			m.typing.newBest = true
+			m.store.Update(m.fingerprint, func(r *visitorRecord) {
+				r.TypingBest = res
+			})
+		}
+	}
+	return m, nil
+}
+
+// wrapRunes splits text into lines of at most width runes, breaking
+// after spaces where possible. It returns [start, end) offsets so the
+// caller can keep per-character state aligned.
+func wrapRunes(text []rune, width int) [][2]int {
+	var lines [][2]int
+	start := 0
+	for start < len(text) {
+		end := start + width
+		if end >= len(text) {
+			lines = append(lines, [2]int{start, len(text)})
+			break
+		}
+		brk := end
+		for i := end; i > start; i-- {
+			if text[i-1] == ' ' {
+				brk = i
+				break
+			}
+		}
+		lines = append(lines, [2]int{start, brk})
+		start = brk
+	}
+	return lines
+}
+
+func (m model) renderTyping(contentWidth int) string {
+	var b strings.Builder
+	t := m.typing
+
+	section := sectionStyle.Render("▸ TYPING TEST")
+	b.WriteString(centerText(section, contentWidth))
+	b.WriteString("\n\n")
+
+	textWidth := contentWidth - 8
+	if textWidth > 56 {
+		textWidth = 56
+	}
+	for _, span := range wrapRunes(t.target, textWidth) {
+		// Style runs of characters in the same state together to keep
+		// the escape sequences down
+		var line strings.Builder
+		var run []rune
+		runState := -1
+		for i := span[0]; i < span[1]; i++ {
+			state := typedStatePending
+			switch {
+			case i < len(t.typed) && t.typed[i] == t.target[i]:
+				state = typedStateCorrect
+			case i < len(t.typed):
+				// Show what was expected so mistyped spaces stay visible
+				state = typedStateWrong
+			case i == len(t.typed):
+				state = typedStateCursor
+			}
+			if state != runState && len(run) > 0 {
+				line.WriteString(typedStyles[runState].Render(string(run)))
+				run = run[:0]
+			}
+			runState = state
+			run = append(run, t.target[i])
+		}
+		line.WriteString(typedStyles[runState].Render(string(run)))
+		b.WriteString(centerText(line.String(), contentWidth))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	stats := fmt.Sprintf("%.0f wpm · %.0f%% accuracy · %.1fs",
+		t.wpm(), t.accuracy(), t.elapsed().Seconds())
+	if t.done {
+		b.WriteString(centerText(titleStyle.Render(stats), contentWidth))
+		b.WriteString("\n")
+		if t.newBest {
+			b.WriteString(centerText(quoteStyle.Render("★ New personal best!"), contentWidth))
+			b.WriteString("\n")
+		}
+	} else if !t.started.IsZero() {
+		b.WriteString(centerText(techStyle.Render(stats), contentWidth))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(centerText(techStyle.Render("Start typing when ready..."), contentWidth))
+		b.WriteString("\n")
+	}
+
+	if m.typingBest.WPM > 0 {
+		best := fmt.Sprintf("personal best: %.0f wpm · %.0f%%", m.typingBest.WPM, m.typingBest.Accuracy)
+		b.WriteString(centerText(socialText.Render(best), contentWidth))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	hints := "tab new quote · esc back"
+	if t.done {
+		hints = "enter again · esc back"
+	}
+	b.WriteString(centerText(hintStyle.Render(hints), contentWidth))
+	return b.String()
+}